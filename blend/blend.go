@@ -1,6 +1,7 @@
 package blend
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/tryoutbounder/soroban-client-golang/blend/types/backstop"
@@ -23,7 +24,14 @@ func NewBlendClient(rpcUrl string) *BlendClient {
 func (bc *BlendClient) BackstopConfig(
 	backstopAddr string,
 ) (*backstop.BackstopConfig, error) {
-	return backstop.LoadConfig(bc.rpc, backstopAddr)
+	return bc.BackstopConfigContext(context.Background(), backstopAddr)
+}
+
+func (bc *BlendClient) BackstopConfigContext(
+	ctx context.Context,
+	backstopAddr string,
+) (*backstop.BackstopConfig, error) {
+	return backstop.LoadConfigContext(ctx, bc.rpc, backstopAddr)
 }
 
 // Load token price, makeup, and analytics
@@ -32,7 +40,22 @@ func (bc *BlendClient) BackstopToken(
 	blndTokenContract string,
 	usdcTokenContract string,
 ) (*backstop.BackstopToken, error) {
-	return backstop.LoadToken(
+	return bc.BackstopTokenContext(
+		context.Background(),
+		cometContract,
+		blndTokenContract,
+		usdcTokenContract,
+	)
+}
+
+func (bc *BlendClient) BackstopTokenContext(
+	ctx context.Context,
+	cometContract string,
+	blndTokenContract string,
+	usdcTokenContract string,
+) (*backstop.BackstopToken, error) {
+	return backstop.LoadTokenContext(
+		ctx,
 		bc.rpc,
 		cometContract,
 		blndTokenContract,
@@ -45,7 +68,20 @@ func (bc *BlendClient) BackstopPoolBalance(
 	backstopContract string,
 	poolContract string,
 ) (*backstop.BackstopPoolBalance, error) {
-	return backstop.LoadPoolBalance(
+	return bc.BackstopPoolBalanceContext(
+		context.Background(),
+		backstopContract,
+		poolContract,
+	)
+}
+
+func (bc *BlendClient) BackstopPoolBalanceContext(
+	ctx context.Context,
+	backstopContract string,
+	poolContract string,
+) (*backstop.BackstopPoolBalance, error) {
+	return backstop.LoadPoolBalanceContext(
+		ctx,
 		bc.rpc,
 		backstopContract,
 		poolContract,
@@ -58,7 +94,22 @@ func (bc *BlendClient) BackstopPoolUser(
 	poolContract string,
 	userAddress string,
 ) (*backstop.BackstopPoolUser, error) {
-	return backstop.LoadBackstopUser(
+	return bc.BackstopPoolUserContext(
+		context.Background(),
+		backstopContract,
+		poolContract,
+		userAddress,
+	)
+}
+
+func (bc *BlendClient) BackstopPoolUserContext(
+	ctx context.Context,
+	backstopContract string,
+	poolContract string,
+	userAddress string,
+) (*backstop.BackstopPoolUser, error) {
+	return backstop.LoadBackstopUserContext(
+		ctx,
 		bc.rpc,
 		backstopContract,
 		poolContract,
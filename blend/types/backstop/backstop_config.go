@@ -1,12 +1,14 @@
 package backstop
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/stellar/go/xdr"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 )
 
@@ -23,81 +25,34 @@ func LoadConfig(
 	rpc *soroban.RpcClient,
 	backstopContract string,
 ) (*BackstopConfig, error) {
-	backstopAddress, err := helpers.ContractAddressToScAddress(backstopContract)
+	return LoadConfigContext(context.Background(), rpc, backstopContract)
+}
+
+func LoadConfigContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	backstopContract string,
+) (*BackstopConfig, error) {
+	contractDataLedgerKey, err := scv.InstanceKey(backstopContract)
 	if err != nil {
 		return nil, err
 	}
 
-	contractDataLedgerKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract: backstopAddress,
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvLedgerKeyContractInstance,
-			},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
-	}
-
-	rewardZoneSymbol := xdr.ScSymbol("RZ")
-	rewardZoneLedgerKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract: backstopAddress,
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &rewardZoneSymbol,
-			},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
+	rewardZoneLedgerKey, err := scv.PersistentKey(backstopContract, scv.Sym("RZ"))
+	if err != nil {
+		return nil, err
 	}
 
 	ledgerKeys := []xdr.LedgerKey{contractDataLedgerKey, rewardZoneLedgerKey}
 
-	entries, err := executor.LedgerEntryCall(rpc, backstopAddress, ledgerKeys)
+	entries, err := executor.LedgerEntriesCall(ctx, rpc, ledgerKeys, executor.DefaultLedgerEntriesOptions())
 
 	if err != nil {
 		return nil, err
 	}
 
-	contractDataFound := false
-	rewardZoneFound := false
-
-	backstopConfig := &BackstopConfig{}
-	for ledgerKey, entry := range entries {
-		if ledgerKey.Equals(contractDataLedgerKey) {
-			contractDataFound = true
-			contractData := entry.ContractData
-			if contractData == nil {
-				return nil, fmt.Errorf("contract data is nil")
-			}
-			configData := contractData.Val.Instance.Storage
-			backstopConfigData, err := extractBackstopConfigData(configData)
-			if err != nil {
-				return nil, err
-			}
-
-			backstopConfig.BlndTkn = backstopConfigData.BlndTkn
-			backstopConfig.UsdcTkn = backstopConfigData.UsdcTkn
-			backstopConfig.BackstopTkn = backstopConfigData.BackstopTkn
-			backstopConfig.PoolFactory = backstopConfigData.PoolFactory
-			backstopConfig.PublicEmitter = backstopConfigData.PublicEmitter
-
-		}
-
-		if ledgerKey.Equals(rewardZoneLedgerKey) {
-			rewardZoneFound = true
-			rewardZoneData := entry.ContractData
-
-			rewardZoneAddresses, err := extractRewardZoneData(rewardZoneData)
-			if err != nil {
-				return nil, err
-			}
-
-			backstopConfig.RewardZone = rewardZoneAddresses
-		}
-
-	}
+	contractDataEntry, contractDataFound := entries.Get(contractDataLedgerKey)
+	rewardZoneEntry, rewardZoneFound := entries.Get(rewardZoneLedgerKey)
 
 	if !contractDataFound || !rewardZoneFound {
 		msg := "missing data:"
@@ -111,6 +66,38 @@ func LoadConfig(
 		return nil, fmt.Errorf("%s %s", msg, strings.Join(missingItems, ", "))
 	}
 
+	contractData := contractDataEntry.Data.ContractData
+	if contractData == nil {
+		return nil, fmt.Errorf("contract data is nil")
+	}
+	instance, ok := contractData.Val.GetInstance()
+	if !ok {
+		return nil, fmt.Errorf("backstop contract data is not a contract instance")
+	}
+	configData := instance.Storage
+	if configData == nil {
+		return nil, fmt.Errorf("backstop instance storage is empty")
+	}
+	backstopConfigData, err := extractBackstopConfigData(configData)
+	if err != nil {
+		return nil, err
+	}
+
+	backstopConfig := &BackstopConfig{
+		BlndTkn:       backstopConfigData.BlndTkn,
+		UsdcTkn:       backstopConfigData.UsdcTkn,
+		BackstopTkn:   backstopConfigData.BackstopTkn,
+		PoolFactory:   backstopConfigData.PoolFactory,
+		PublicEmitter: backstopConfigData.PublicEmitter,
+	}
+
+	rewardZoneAddresses, err := extractRewardZoneData(rewardZoneEntry.Data.ContractData)
+	if err != nil {
+		return nil, err
+	}
+
+	backstopConfig.RewardZone = rewardZoneAddresses
+
 	return backstopConfig, nil
 }
 
@@ -120,7 +107,7 @@ func extractRewardZoneData(rewardZoneData *xdr.ContractDataEntry) ([]string, err
 	}
 	vec, ok := rewardZoneData.Val.GetVec()
 
-	if !ok {
+	if !ok || vec == nil {
 		return nil, fmt.Errorf("reward zone list is nil")
 	}
 
@@ -131,14 +118,14 @@ func extractRewardZoneData(rewardZoneData *xdr.ContractDataEntry) ([]string, err
 	for i, addr := range rewardZoneList {
 		address, ok := addr.GetAddress()
 		if !ok {
-			return nil, fmt.Errorf("address.ContractId is nil")
+			return nil, fmt.Errorf("reward zone item %d is not an address", i)
 		}
-		contractId, err := helpers.EncodeContractAddress(*address.ContractId)
+		encoded, err := helpers.EncodeScAddress(address)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("reward zone item %d: %w", i, err)
 		}
 
-		rewardZoneAddresses[i] = contractId
+		rewardZoneAddresses[i] = encoded
 
 	}
 
@@ -146,73 +133,25 @@ func extractRewardZoneData(rewardZoneData *xdr.ContractDataEntry) ([]string, err
 
 }
 
+// backstopConfigData is the backstop's instance storage, keyed by the
+// contract's storage symbols.
 type backstopConfigData struct {
-	PublicEmitter string
-	BlndTkn       string
-	UsdcTkn       string
-	BackstopTkn   string
-	PoolFactory   string
+	PublicEmitter string `soroban:"Emitter,address,required"`
+	BlndTkn       string `soroban:"BLNDTkn,address,required"`
+	UsdcTkn       string `soroban:"USDCTkn,address,required"`
+	BackstopTkn   string `soroban:"BToken,address,required"`
+	PoolFactory   string `soroban:"PoolFact,address,required"`
 }
 
 func extractBackstopConfigData(configData *xdr.ScMap) (*backstopConfigData, error) {
-	mapData := *configData
-	if mapData == nil {
-		return nil, fmt.Errorf("config map is nil")
-	}
-
-	data := backstopConfigData{}
-
-	for _, v := range mapData {
-		key := v.Key
-		val := v.Val
-
-		if key.Type != xdr.ScValTypeScvSymbol {
-			return nil, fmt.Errorf("config key is not a symbol type")
-		}
-
-		symbol := *key.Sym
-		switch symbol {
-		case "BLNDTkn":
-			contractId, err := helpers.EncodeContractAddress(*val.Address.ContractId)
-			if err != nil {
-				return nil, err
-			}
-			data.BlndTkn = contractId
-		case "BToken":
-			contractId, err := helpers.EncodeContractAddress(*val.Address.ContractId)
-			if err != nil {
-				return nil, err
-			}
-			data.BackstopTkn = contractId
-		case "USDCTkn":
-			contractId, err := helpers.EncodeContractAddress(*val.Address.ContractId)
-			if err != nil {
-				return nil, err
-			}
-			data.UsdcTkn = contractId
-		case "PoolFact":
-			contractId, err := helpers.EncodeContractAddress(*val.Address.ContractId)
-			if err != nil {
-				return nil, err
-			}
-			data.PoolFactory = contractId
-		case "Emitter":
-			contractId, err := helpers.EncodeContractAddress(*val.Address.ContractId)
-			if err != nil {
-				return nil, err
-			}
-			data.PublicEmitter = contractId
-		case "IsInit":
-			// do nothing
-		default:
-			return nil, fmt.Errorf("invalid backstop instance storage key: should not contain %s", symbol)
-		}
-
+	val, err := xdr.NewScVal(xdr.ScValTypeScvMap, configData)
+	if err != nil {
+		return nil, err
 	}
 
-	if data.BlndTkn == "" || data.UsdcTkn == "" || data.BackstopTkn == "" || data.PoolFactory == "" || data.PublicEmitter == "" {
-		return nil, fmt.Errorf("incomplete backstop configuration: missing one or more required fields")
+	var data backstopConfigData
+	if err := helpers.UnmarshalScVal(val, &data); err != nil {
+		return nil, fmt.Errorf("invalid backstop configuration: %w", err)
 	}
-
 	return &data, nil
 }
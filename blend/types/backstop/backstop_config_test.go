@@ -0,0 +1,52 @@
+package backstop
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractBackstopConfigData(t *testing.T) {
+	contract := func(b byte) string {
+		return strkey.MustEncode(strkey.VersionByteContract, bytes.Repeat([]byte{b}, 32))
+	}
+	field := func(name string, val xdr.ScVal) xdr.ScMapEntry {
+		sym := xdr.ScSymbol(name)
+		return xdr.ScMapEntry{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, Val: val}
+	}
+	address := func(b byte) xdr.ScVal {
+		contractId := xdr.ContractId(bytes.Repeat([]byte{b}, 32))
+		return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &xdr.ScAddress{
+			Type:       xdr.ScAddressTypeScAddressTypeContract,
+			ContractId: &contractId,
+		}}
+	}
+	isInit := true
+	fields := xdr.ScMap{
+		field("BLNDTkn", address(1)),
+		field("BToken", address(2)),
+		field("Emitter", address(3)),
+		field("IsInit", xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &isInit}),
+		field("PoolFact", address(4)),
+		field("USDCTkn", address(5)),
+	}
+
+	data, err := extractBackstopConfigData(&fields)
+	require.NoError(t, err)
+	assert.Equal(t, backstopConfigData{
+		PublicEmitter: contract(3),
+		BlndTkn:       contract(1),
+		UsdcTkn:       contract(5),
+		BackstopTkn:   contract(2),
+		PoolFactory:   contract(4),
+	}, *data)
+
+	// Without the pool factory.
+	withoutFactory := append(fields[:4:4], fields[5])
+	_, err = extractBackstopConfigData(&withoutFactory)
+	assert.ErrorContains(t, err, "$.PoolFact: missing required field")
+}
@@ -0,0 +1,188 @@
+package backstop
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/blend/types"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// Backstop event names, the symbol in each event's first topic.
+const (
+	EventDeposit           = "deposit"
+	EventWithdraw          = "withdraw"
+	EventQueueWithdrawal   = "queue_withdrawal"
+	EventDequeueWithdrawal = "dequeue_withdrawal"
+)
+
+// DepositEvent is emitted when a user deposits backstop tokens for a pool.
+// Token and share amounts in this and the other backstop events are
+// Decimals with types.TOKEN_DECIMALS decimals, as the backstop balances are.
+type DepositEvent struct {
+	Record       executor.EventRecord
+	PoolAddress  string
+	From         string
+	TokensIn     helpers.Decimal
+	SharesMinted helpers.Decimal
+}
+
+type WithdrawEvent struct {
+	Record      executor.EventRecord
+	PoolAddress string
+	From        string
+	SharesBurnt helpers.Decimal
+	TokensOut   helpers.Decimal
+}
+
+type QueueWithdrawalEvent struct {
+	Record      executor.EventRecord
+	PoolAddress string
+	From        string
+	Shares      helpers.Decimal
+	Expiration  time.Time
+}
+
+type DequeueWithdrawalEvent struct {
+	Record      executor.EventRecord
+	PoolAddress string
+	From        string
+	Shares      helpers.Decimal
+}
+
+// RegisterBackstopEvents registers decoders for the deposit and withdrawal
+// events of backstopContract. An empty backstopContract decodes them from any
+// contract.
+func RegisterBackstopEvents(registry *executor.EventRegistry, backstopContract string) {
+	executor.RegisterEventDecoder(registry, backstopContract, EventDeposit, DecodeDepositEvent)
+	executor.RegisterEventDecoder(registry, backstopContract, EventWithdraw, DecodeWithdrawEvent)
+	executor.RegisterEventDecoder(registry, backstopContract, EventQueueWithdrawal, DecodeQueueWithdrawalEvent)
+	executor.RegisterEventDecoder(registry, backstopContract, EventDequeueWithdrawal, DecodeDequeueWithdrawalEvent)
+}
+
+func DecodeDepositEvent(record executor.EventRecord) (*DepositEvent, error) {
+	pool, from, err := eventParticipants(record)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := types.EventDataVec(record, 2)
+	if err != nil {
+		return nil, err
+	}
+	tokensIn, err := eventAmount(data[0], "tokens in")
+	if err != nil {
+		return nil, err
+	}
+	sharesMinted, err := eventAmount(data[1], "shares minted")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DepositEvent{
+		Record:       record,
+		PoolAddress:  pool,
+		From:         from,
+		TokensIn:     tokensIn,
+		SharesMinted: sharesMinted,
+	}, nil
+}
+
+func DecodeWithdrawEvent(record executor.EventRecord) (*WithdrawEvent, error) {
+	pool, from, err := eventParticipants(record)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := types.EventDataVec(record, 2)
+	if err != nil {
+		return nil, err
+	}
+	sharesBurnt, err := eventAmount(data[0], "shares burnt")
+	if err != nil {
+		return nil, err
+	}
+	tokensOut, err := eventAmount(data[1], "tokens out")
+	if err != nil {
+		return nil, err
+	}
+
+	return &WithdrawEvent{
+		Record:      record,
+		PoolAddress: pool,
+		From:        from,
+		SharesBurnt: sharesBurnt,
+		TokensOut:   tokensOut,
+	}, nil
+}
+
+func DecodeQueueWithdrawalEvent(record executor.EventRecord) (*QueueWithdrawalEvent, error) {
+	pool, from, err := eventParticipants(record)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := types.EventDataVec(record, 2)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := eventAmount(data[0], "shares")
+	if err != nil {
+		return nil, err
+	}
+	expiration, ok := data[1].GetU64()
+	if !ok {
+		return nil, fmt.Errorf("expected u64 expiration in queue_withdrawal event %s", record.ID)
+	}
+
+	return &QueueWithdrawalEvent{
+		Record:      record,
+		PoolAddress: pool,
+		From:        from,
+		Shares:      shares,
+		Expiration:  time.Unix(int64(expiration), 0),
+	}, nil
+}
+
+func DecodeDequeueWithdrawalEvent(record executor.EventRecord) (*DequeueWithdrawalEvent, error) {
+	pool, from, err := eventParticipants(record)
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := eventAmount(record.Body, "shares")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DequeueWithdrawalEvent{
+		Record:      record,
+		PoolAddress: pool,
+		From:        from,
+		Shares:      shares,
+	}, nil
+}
+
+// eventParticipants returns the pool and user addresses every backstop
+// deposit and withdrawal event carries in its topics.
+func eventParticipants(record executor.EventRecord) (string, string, error) {
+	pool, err := types.EventTopicAddress(record, 1)
+	if err != nil {
+		return "", "", err
+	}
+	from, err := types.EventTopicAddress(record, 2)
+	if err != nil {
+		return "", "", err
+	}
+	return pool, from, nil
+}
+
+func eventAmount(val xdr.ScVal, name string) (helpers.Decimal, error) {
+	i128, ok := val.GetI128()
+	if !ok {
+		return helpers.Decimal{}, fmt.Errorf("expected i128 value for %s", name)
+	}
+	return helpers.DecimalFromI128(i128, types.TOKEN_DECIMALS), nil
+}
@@ -0,0 +1,57 @@
+package backstop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/blend/types"
+	"github.com/tryoutbounder/soroban-client-golang/blend/types/internal/eventtest"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+func tokens(unscaled int64) helpers.Decimal {
+	return helpers.NewDecimalFromInt64(unscaled, types.TOKEN_DECIMALS)
+}
+
+func TestDecodeBackstopEvents(t *testing.T) {
+	large := eventtest.Large()
+
+	deposit, err := DecodeDepositEvent(eventtest.Record(EventDeposit, "["+large.String()+"i128, 5i128]"))
+	require.NoError(t, err)
+	assert.Equal(t, eventtest.Contract, deposit.PoolAddress)
+	assert.Equal(t, eventtest.User, deposit.From)
+	assert.Equal(t, helpers.NewDecimal(large, types.TOKEN_DECIMALS), deposit.TokensIn)
+	assert.Equal(t, "12345678901234.5678901", deposit.TokensIn.String())
+	assert.Equal(t, tokens(5), deposit.SharesMinted)
+
+	withdraw, err := DecodeWithdrawEvent(eventtest.Record(EventWithdraw, "[7i128, -1i128]"))
+	require.NoError(t, err)
+	assert.Equal(t, tokens(7), withdraw.SharesBurnt)
+	assert.Equal(t, tokens(-1), withdraw.TokensOut)
+
+	queued, err := DecodeQueueWithdrawalEvent(eventtest.Record(EventQueueWithdrawal, "[30i128, 1700000000u64]"))
+	require.NoError(t, err)
+	assert.Equal(t, tokens(30), queued.Shares)
+	assert.Equal(t, time.Unix(1700000000, 0), queued.Expiration)
+
+	dequeued, err := DecodeDequeueWithdrawalEvent(eventtest.Record(EventDequeueWithdrawal, "30i128"))
+	require.NoError(t, err)
+	assert.Equal(t, eventtest.Contract, dequeued.PoolAddress)
+	assert.Equal(t, tokens(30), dequeued.Shares)
+}
+
+func TestDecodeBackstopEventErrors(t *testing.T) {
+	_, err := DecodeDepositEvent(eventtest.Record(EventDeposit, "[1i128]"))
+	assert.Error(t, err)
+
+	_, err = DecodeWithdrawEvent(eventtest.Record(EventWithdraw, "[1u64, 2i128]"))
+	assert.ErrorContains(t, err, "shares burnt")
+
+	record := eventtest.Record(EventDequeueWithdrawal, "1i128")
+	record.Topics = record.Topics[:2]
+	_, err = DecodeDequeueWithdrawalEvent(record)
+	assert.Error(t, err)
+}
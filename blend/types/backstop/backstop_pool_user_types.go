@@ -1,6 +1,7 @@
 package backstop
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -8,24 +9,25 @@ import (
 	"github.com/tryoutbounder/soroban-client-golang/blend/types"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 )
 
 type Q4W struct {
-	Amount     float64
-	Expiration time.Time
+	Amount     helpers.Decimal `soroban:"amount,i128,decimals=7"`
+	Expiration time.Time       `soroban:"exp,u64"`
 }
 
 type BackstopUserBalance struct {
-	Shares      float64
-	Q4W         []Q4W
-	UnlockedQ4W float64
-	TotalQ4W    float64
+	Shares      helpers.Decimal `soroban:"shares,i128,decimals=7"`
+	Q4W         []Q4W           `soroban:"q4w"`
+	UnlockedQ4W helpers.Decimal `soroban:"-"`
+	TotalQ4W    helpers.Decimal `soroban:"-"`
 }
 
 type BackstopUserEmissions struct {
-	Index   int64
-	Accrued float64
+	Index   int64           `soroban:"index,i128"`
+	Accrued helpers.Decimal `soroban:"accrued,i128,decimals=7"`
 }
 type BackstopPoolUser struct {
 	Balance   *BackstopUserBalance
@@ -39,296 +41,89 @@ func LoadBackstopUser(
 	userAddress string,
 
 ) (*BackstopPoolUser, error) {
-	backstopAddress, err := helpers.ContractAddressToScAddress(backstopContract)
-	if err != nil {
-		return nil, err
-	}
+	return LoadBackstopUserContext(context.Background(), rpc, backstopContract, poolContract, userAddress)
+}
 
-	poolAddress, err := helpers.ContractAddressToScAddress(poolContract)
+func LoadBackstopUserContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	backstopContract string,
+	poolContract string,
+	userAddress string,
+
+) (*BackstopPoolUser, error) {
+	userBalanceKey, err := scv.PersistentKey(backstopContract, scv.Vec(
+		scv.Sym("UserBalance"),
+		scv.Map(
+			scv.Field("pool", scv.Address(poolContract)),
+			scv.Field("user", scv.Address(userAddress)),
+		),
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	userScAddress, err := helpers.StellarAddressToScAddress(userAddress)
+	uEmisDataKey, err := scv.PersistentKey(backstopContract, scv.Vec(
+		scv.Sym("UEmisData"),
+		scv.Map(
+			scv.Field("pool", scv.Address(poolContract)),
+			scv.Field("user", scv.Address(userAddress)),
+		),
+	))
 	if err != nil {
 		return nil, err
 	}
 
-	// Create UserBalance ledger key
-	userBalanceSymbol := xdr.ScSymbol("UserBalance")
-	poolSymbol := xdr.ScSymbol("pool")
-	userSymbol := xdr.ScSymbol("user")
-
-	userBalanceMap := &xdr.ScMap{
-		xdr.ScMapEntry{
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &poolSymbol,
-			},
-			Val: xdr.ScVal{
-				Type:    xdr.ScValTypeScvAddress,
-				Address: &poolAddress,
-			},
-		},
-		xdr.ScMapEntry{
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &userSymbol,
-			},
-			Val: xdr.ScVal{
-				Type:    xdr.ScValTypeScvAddress,
-				Address: &userScAddress,
-			},
-		},
-	}
-
-	userBalanceKeyVec := &xdr.ScVec{
-		xdr.ScVal{
-			Type: xdr.ScValTypeScvSymbol,
-			Sym:  &userBalanceSymbol,
-		},
-		xdr.ScVal{
-			Type: xdr.ScValTypeScvMap,
-			Map:  &userBalanceMap,
-		},
-	}
-
-	userBalanceKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract:   backstopAddress,
-			Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &userBalanceKeyVec},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
-	}
-
-	// Create UEmisData ledger key
-	uEmisDataSymbol := xdr.ScSymbol("UEmisData")
-
-	uEmisDataMap := &xdr.ScMap{
-		xdr.ScMapEntry{
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &poolSymbol,
-			},
-			Val: xdr.ScVal{
-				Type:    xdr.ScValTypeScvAddress,
-				Address: &poolAddress,
-			},
-		},
-		xdr.ScMapEntry{
-			Key: xdr.ScVal{
-				Type: xdr.ScValTypeScvSymbol,
-				Sym:  &userSymbol,
-			},
-			Val: xdr.ScVal{
-				Type:    xdr.ScValTypeScvAddress,
-				Address: &userScAddress,
-			},
-		},
-	}
-
-	uEmisDataKeyVec := &xdr.ScVec{
-		xdr.ScVal{
-			Type: xdr.ScValTypeScvSymbol,
-			Sym:  &uEmisDataSymbol,
-		},
-		xdr.ScVal{
-			Type: xdr.ScValTypeScvMap,
-			Map:  &uEmisDataMap,
-		},
-	}
-
-	uEmisDataKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract:   backstopAddress,
-			Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &uEmisDataKeyVec},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
-	}
-
 	ledgerKeys := []xdr.LedgerKey{userBalanceKey, uEmisDataKey}
 
-	entries, err := executor.LedgerEntryCall(rpc, backstopAddress, ledgerKeys)
+	entries, err := executor.LedgerEntriesCall(ctx, rpc, ledgerKeys, executor.DefaultLedgerEntriesOptions())
 	if err != nil {
 		return nil, err
 	}
 
 	backstopUser := &BackstopPoolUser{}
 
-	for ledgerKey, entry := range entries {
-		if entry.ContractData == nil {
-			return nil, fmt.Errorf("contract data is nil for ledger entry")
+	if entry, ok := entries.Get(userBalanceKey); ok {
+		balance := &BackstopUserBalance{}
+		if err := decodeContractData(entry, balance); err != nil {
+			return nil, fmt.Errorf("error decoding backstop user balance: %w", err)
 		}
 
-		data, ok := entry.ContractData.Val.GetMap()
-		if !ok {
-			return nil, fmt.Errorf("contract data val is not a map")
-		}
-
-		if data == nil {
-			return nil, fmt.Errorf("contract data map is nil")
-		}
+		// Calculate total and unlocked Q4W
+		totalQ4W := helpers.NewDecimalFromInt64(0, types.TOKEN_DECIMALS)
+		unlockedQ4W := helpers.NewDecimalFromInt64(0, types.TOKEN_DECIMALS)
+		currentTime := time.Now()
 
-		if ledgerKey.Equals(userBalanceKey) {
-			balance, err := extractUserBalance(*data)
-			if err != nil {
-				return nil, err
+		for _, q4w := range balance.Q4W {
+			totalQ4W = totalQ4W.Add(q4w.Amount)
+			if currentTime.After(q4w.Expiration) {
+				unlockedQ4W = unlockedQ4W.Add(q4w.Amount)
 			}
-
-			// Calculate total and unlocked Q4W
-			totalQ4W := 0.0
-			unlockedQ4W := 0.0
-			currentTime := time.Now()
-
-			for _, q4w := range balance.Q4W {
-				totalQ4W += q4w.Amount
-				if currentTime.After(q4w.Expiration) {
-					unlockedQ4W += q4w.Amount
-				}
-			}
-
-			balance.TotalQ4W = totalQ4W
-			balance.UnlockedQ4W = unlockedQ4W
-
-			backstopUser.Balance = balance
-
 		}
 
-		if ledgerKey.Equals(uEmisDataKey) {
-			emissions, err := extractUserEmissions(*data)
-			if err != nil {
-				return nil, err
-			}
-
-			backstopUser.Emissions = emissions
-
-		}
+		balance.TotalQ4W = totalQ4W
+		balance.UnlockedQ4W = unlockedQ4W
 
+		backstopUser.Balance = balance
 	}
 
-	return backstopUser, nil
-}
-
-func extractUserBalance(data xdr.ScMap) (*BackstopUserBalance, error) {
-	balance := &BackstopUserBalance{}
-	for _, scVal := range data {
-		key, ok := scVal.Key.GetSym()
-		if !ok {
-			return nil, fmt.Errorf("failed to get symbol from key")
-		}
-		val := scVal.Val
-
-		switch key {
-		case "shares":
-			i128, ok := val.GetI128()
-			if !ok {
-				return nil, fmt.Errorf("shares val is not an i128")
-			}
-
-			balance.Shares = helpers.I128ToFloat64(i128, types.SCALAR_7)
-
-		case "q4w":
-			vec, ok := val.GetVec()
-			if !ok {
-				return nil, fmt.Errorf("q4w val is not a vector")
-			}
-
-			if vec == nil {
-				return nil, fmt.Errorf("q4w vec is nil")
-			}
-
-			queuedWithdrawals := make([]Q4W, len(*vec))
-			for i, queued := range *vec {
-				q4wMap, ok := queued.GetMap()
-				if !ok {
-					return nil, fmt.Errorf("queued item is not a map")
-				}
-
-				q4w, err := extractQ4wData(*q4wMap)
-				if err != nil {
-					return nil, err
-				}
-
-				queuedWithdrawals[i] = *q4w
-
-			}
-			balance.Q4W = queuedWithdrawals
-
+	if entry, ok := entries.Get(uEmisDataKey); ok {
+		emissions := &BackstopUserEmissions{}
+		if err := decodeContractData(entry, emissions); err != nil {
+			return nil, fmt.Errorf("error decoding backstop user emissions: %w", err)
 		}
 
+		backstopUser.Emissions = emissions
 	}
 
-	return balance, nil
-
-}
-
-func extractUserEmissions(data xdr.ScMap) (*BackstopUserEmissions, error) {
-	emissions := &BackstopUserEmissions{}
-	for _, scVal := range data {
-		key, ok := scVal.Key.GetSym()
-		if !ok {
-			return nil, fmt.Errorf("failed to get symbol from key")
-		}
-
-		val := scVal.Val
-
-		switch key {
-		case "accrued":
-			i128, ok := val.GetI128()
-			if !ok {
-				return nil, fmt.Errorf("accrued val is not an i128")
-			}
-
-			emissions.Accrued = helpers.I128ToFloat64(i128, types.SCALAR_7)
-
-		case "index":
-			i128, ok := val.GetI128()
-			if !ok {
-				return nil, fmt.Errorf("index val is not an i128")
-			}
-
-			emissions.Index = helpers.I128ToInt64(i128)
-		}
-
-	}
-
-	return emissions, nil
-
+	return backstopUser, nil
 }
 
-func extractQ4wData(data xdr.ScMap) (*Q4W, error) {
-	q4w := Q4W{}
-	for _, scVal := range data {
-		key, ok := scVal.Key.GetSym()
-		if !ok {
-			return nil, fmt.Errorf("failed to get symbol from key")
-		}
-
-		val := scVal.Val
-
-		switch key {
-		case "amount":
-			i128, ok := val.GetI128()
-			if !ok {
-				return nil, fmt.Errorf("amount val is not an i128")
-			}
-
-			q4w.Amount = helpers.I128ToFloat64(i128, types.SCALAR_7)
-
-		case "exp":
-			u64, ok := val.GetU64()
-			if !ok {
-				return nil, fmt.Errorf("exp val is not an i128")
-			}
-
-			q4w.Expiration = time.Unix(int64(u64), 0)
-
-		}
-
+// decodeContractData decodes the value of a contract data entry into out.
+func decodeContractData(entry executor.LedgerEntry, out any) error {
+	if entry.Data.ContractData == nil {
+		return fmt.Errorf("contract data is nil for ledger entry")
 	}
 
-	return &q4w, nil
-
+	return helpers.UnmarshalScVal(entry.Data.ContractData.Val, out)
 }
@@ -1,23 +1,25 @@
 package backstop
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/stellar/go/xdr"
 	"github.com/tryoutbounder/soroban-client-golang/blend/types"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 )
 
 type BackstopToken struct {
 	ID             string
-	BLND           float64
-	USDC           float64
-	Shares         float64
-	BLNDPerLPToken float64
-	USDCPerLPToken float64
-	LPTokenPrice   float64
+	BLND           helpers.Decimal
+	USDC           helpers.Decimal
+	Shares         helpers.Decimal
+	BLNDPerLPToken helpers.Decimal
+	USDCPerLPToken helpers.Decimal
+	LPTokenPrice   helpers.Decimal
 }
 
 func LoadToken(
@@ -26,10 +28,16 @@ func LoadToken(
 	blndTokenContract string,
 	usdcTokenContract string,
 ) (*BackstopToken, error) {
-	backstopTokenAddress, err := helpers.ContractAddressToScAddress(cometContract)
-	if err != nil {
-		return nil, err
-	}
+	return LoadTokenContext(context.Background(), rpc, cometContract, blndTokenContract, usdcTokenContract)
+}
+
+func LoadTokenContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	cometContract string,
+	blndTokenContract string,
+	usdcTokenContract string,
+) (*BackstopToken, error) {
 	blndTokenAddress, err := helpers.ContractAddressToScAddress(blndTokenContract)
 	if err != nil {
 		return nil, err
@@ -40,113 +48,102 @@ func LoadToken(
 		return nil, err
 	}
 
-	recordSymbol := xdr.ScSymbol("AllRecordData")
-	totalSharesSymbol := xdr.ScSymbol("TotalShares")
-
-	recordKeyVec := &xdr.ScVec{
-		{Type: xdr.ScValTypeScvSymbol, Sym: &recordSymbol},
-	}
-
-	totalSharesKeyVec := &xdr.ScVec{
-		{Type: xdr.ScValTypeScvSymbol, Sym: &totalSharesSymbol},
-	}
-
-	recordDataKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract:   backstopTokenAddress,
-			Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &recordKeyVec},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
+	recordDataKey, err := scv.PersistentKey(cometContract, scv.Vec(scv.Sym("AllRecordData")))
+	if err != nil {
+		return nil, err
 	}
 
-	totalSharesKey := xdr.LedgerKey{
-		Type: xdr.LedgerEntryTypeContractData,
-		ContractData: &xdr.LedgerKeyContractData{
-			Contract:   backstopTokenAddress,
-			Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &totalSharesKeyVec},
-			Durability: xdr.ContractDataDurabilityPersistent,
-		},
+	totalSharesKey, err := scv.PersistentKey(cometContract, scv.Vec(scv.Sym("TotalShares")))
+	if err != nil {
+		return nil, err
 	}
 
 	ledgerKeys := []xdr.LedgerKey{recordDataKey, totalSharesKey}
 
-	entries, err := executor.LedgerEntryCall(rpc, backstopTokenAddress, ledgerKeys)
+	entries, err := executor.LedgerEntriesCall(ctx, rpc, ledgerKeys, executor.DefaultLedgerEntriesOptions())
 
 	if err != nil {
 		return nil, err
 	}
 
-	recordDataFound := false
-	totalSharesFound := false
+	recordDataEntry, recordDataFound := entries.Get(recordDataKey)
+	totalSharesEntry, totalSharesFound := entries.Get(totalSharesKey)
+
+	if !recordDataFound || !totalSharesFound {
+		return nil, fmt.Errorf("could not find all required ledger entries")
+	}
 
 	tokenData := &BackstopToken{
 		ID: cometContract,
 	}
-	for ledgerKey, entry := range entries {
-		if ledgerKey.Equals(recordDataKey) {
-			recordDataFound = true
-			recordData := entry.ContractData
-			if recordData == nil {
-				return nil, fmt.Errorf("record data is nil")
-			}
 
-			blndBalance, usdcBalance, err := extractTokenBalances(recordData, blndTokenAddress, usdcTokenAddress)
-			if err != nil {
-				return nil, err
-			}
+	recordData := recordDataEntry.Data.ContractData
+	if recordData == nil {
+		return nil, fmt.Errorf("record data is nil")
+	}
 
-			tokenData.BLND = blndBalance
-			tokenData.USDC = usdcBalance
-		}
+	blndBalance, usdcBalance, err := extractTokenBalances(recordData, blndTokenAddress, usdcTokenAddress)
+	if err != nil {
+		return nil, err
+	}
 
-		if ledgerKey.Equals(totalSharesKey) {
-			totalSharesFound = true
-			totalSharesData := entry.ContractData
+	tokenData.BLND = blndBalance
+	tokenData.USDC = usdcBalance
 
-			shares, err := extractTotalShares(totalSharesData)
-			if err != nil {
-				return nil, err
-			}
+	shares, err := extractTotalShares(totalSharesEntry.Data.ContractData)
+	if err != nil {
+		return nil, err
+	}
 
-			tokenData.Shares = shares
-			tokenData.BLNDPerLPToken = float64(tokenData.BLND) / float64(tokenData.Shares)
-			tokenData.USDCPerLPToken = float64(tokenData.USDC) / float64(tokenData.Shares)
-			tokenData.LPTokenPrice = (float64(tokenData.USDC) * 5) / float64(tokenData.Shares)
-		}
+	tokenData.Shares = shares
+	if shares.IsZero() {
+		return tokenData, nil
 	}
 
-	if !recordDataFound || !totalSharesFound {
-		return nil, fmt.Errorf("could not find all required ledger entries")
+	// The comet pool is weighted 80/20 BLND/USDC, so the whole pool is worth
+	// five times its USDC.
+	tokenData.BLNDPerLPToken, err = tokenData.BLND.Div(shares, helpers.RoundFloor)
+	if err != nil {
+		return nil, err
+	}
+	tokenData.USDCPerLPToken, err = tokenData.USDC.Div(shares, helpers.RoundFloor)
+	if err != nil {
+		return nil, err
+	}
+	poolValue := tokenData.USDC.Mul(helpers.NewDecimalFromInt64(5, 0), helpers.RoundFloor)
+	tokenData.LPTokenPrice, err = poolValue.Div(shares, helpers.RoundFloor)
+	if err != nil {
+		return nil, err
 	}
 
 	return tokenData, nil
 }
 
-func extractTokenBalances(recordData *xdr.ContractDataEntry, blndTokenAddress, usdcTokenAddress xdr.ScAddress) (float64, float64, error) {
+func extractTokenBalances(recordData *xdr.ContractDataEntry, blndTokenAddress, usdcTokenAddress xdr.ScAddress) (helpers.Decimal, helpers.Decimal, error) {
 	data, ok := recordData.Val.GetMap()
 	if !ok {
-		return 0, 0, fmt.Errorf("failed to get map from contract data value")
+		return helpers.Decimal{}, helpers.Decimal{}, fmt.Errorf("failed to get map from contract data value")
 	}
 
-	var blndBalance, usdcBalance float64
+	blndBalance := helpers.NewDecimalFromInt64(0, types.TOKEN_DECIMALS)
+	usdcBalance := helpers.NewDecimalFromInt64(0, types.TOKEN_DECIMALS)
 
 	for _, balance := range *data {
 		balanceAddr, ok := balance.Key.GetAddress()
 		if !ok {
-			return 0, 0, fmt.Errorf("failed to get address from balance key")
+			return helpers.Decimal{}, helpers.Decimal{}, fmt.Errorf("failed to get address from balance key")
 		}
 
 		if balanceAddr.Equals(blndTokenAddress) {
 			balanceMap, ok := balance.Val.GetMap()
 			if !ok {
-				return 0, 0, fmt.Errorf("failed to get balance map for BLND")
+				return helpers.Decimal{}, helpers.Decimal{}, fmt.Errorf("failed to get balance map for BLND")
 			}
 			for _, balanceEntry := range *balanceMap {
 				if balanceSymbol, ok := balanceEntry.Key.GetSym(); ok && string(balanceSymbol) == "balance" {
 
 					if blndBal, ok := balanceEntry.Val.GetI128(); ok {
-						blndBalance = helpers.I128ToFloat64(blndBal, types.SCALAR_7)
+						blndBalance = helpers.DecimalFromI128(blndBal, types.TOKEN_DECIMALS)
 					}
 				}
 			}
@@ -155,12 +152,12 @@ func extractTokenBalances(recordData *xdr.ContractDataEntry, blndTokenAddress, u
 		if balanceAddr.Equals(usdcTokenAddress) {
 			balanceMap, ok := balance.Val.GetMap()
 			if !ok {
-				return 0, 0, fmt.Errorf("failed to get balance map for USDC")
+				return helpers.Decimal{}, helpers.Decimal{}, fmt.Errorf("failed to get balance map for USDC")
 			}
 			for _, balanceEntry := range *balanceMap {
 				if balanceSymbol, ok := balanceEntry.Key.GetSym(); ok && string(balanceSymbol) == "balance" {
 					if usdcBal, ok := balanceEntry.Val.GetI128(); ok {
-						usdcBalance = helpers.I128ToFloat64(usdcBal, types.SCALAR_7)
+						usdcBalance = helpers.DecimalFromI128(usdcBal, types.TOKEN_DECIMALS)
 					}
 				}
 			}
@@ -170,15 +167,15 @@ func extractTokenBalances(recordData *xdr.ContractDataEntry, blndTokenAddress, u
 	return blndBalance, usdcBalance, nil
 }
 
-func extractTotalShares(totalSharesData *xdr.ContractDataEntry) (float64, error) {
+func extractTotalShares(totalSharesData *xdr.ContractDataEntry) (helpers.Decimal, error) {
 	if totalSharesData == nil {
-		return 0, fmt.Errorf("total shares data is nil")
+		return helpers.Decimal{}, fmt.Errorf("total shares data is nil")
 	}
 
 	value, ok := totalSharesData.Val.GetI128()
 	if !ok {
-		return 0, fmt.Errorf("failed to get i128 from total shares value")
+		return helpers.Decimal{}, fmt.Errorf("failed to get i128 from total shares value")
 	}
 
-	return helpers.I128ToFloat64(value, types.SCALAR_7), nil
+	return helpers.DecimalFromI128(value, types.TOKEN_DECIMALS), nil
 }
@@ -1,3 +1,7 @@
 package types
 
 const SCALAR_7 = 10000000
+
+// TOKEN_DECIMALS is the number of decimals of BLND, USDC and the backstop
+// token, the scale of SCALAR_7.
+const TOKEN_DECIMALS = 7
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// EventTopicAddress returns the address in topic idx of a Blend event.
+func EventTopicAddress(record executor.EventRecord, idx int) (string, error) {
+	if idx >= len(record.Topics) {
+		return "", fmt.Errorf("event %s has no topic %d", record.ID, idx)
+	}
+
+	address, ok := record.Topics[idx].GetAddress()
+	if !ok {
+		return "", fmt.Errorf("expected address in topic %d of event %s", idx, record.ID)
+	}
+	return helpers.EncodeScAddress(address)
+}
+
+// EventDataVec returns the body of a Blend event published as a tuple of
+// length values.
+func EventDataVec(record executor.EventRecord, length int) ([]xdr.ScVal, error) {
+	vec, ok := record.Body.GetVec()
+	if !ok || vec == nil || len(*vec) != length {
+		return nil, fmt.Errorf("expected a tuple of %d values in event %s", length, record.ID)
+	}
+	return *vec, nil
+}
@@ -0,0 +1,42 @@
+// Package eventtest builds Blend event records for the event decoder tests.
+package eventtest
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
+)
+
+var (
+	// Contract is the contract address in the second topic of a Record, the
+	// pool of backstop events and the asset of pool events.
+	Contract = strkey.MustEncode(strkey.VersionByteContract, bytes.Repeat([]byte{1}, 32))
+	// User is the account address in the third topic of a Record.
+	User = strkey.MustEncode(strkey.VersionByteAccountID, bytes.Repeat([]byte{2}, 32))
+)
+
+// Large returns an i128 amount larger than a float64 holds exactly.
+func Large() *big.Int {
+	large, ok := new(big.Int).SetString("123456789012345678901", 10)
+	if !ok {
+		panic("eventtest: invalid large amount")
+	}
+	return large
+}
+
+// Record returns the event name published with Contract and User as its
+// topics and body, in scv syntax, as its data.
+func Record(name string, body string) executor.EventRecord {
+	return executor.EventRecord{
+		ID: "0000000042-0000000001",
+		Event: executor.Event{
+			Topics: []xdr.ScVal{scv.MustParse(name), scv.MustParse(Contract), scv.MustParse(User)},
+			Body:   scv.MustParse(body),
+		},
+	}
+}
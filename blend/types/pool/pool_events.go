@@ -0,0 +1,93 @@
+package pool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/tryoutbounder/soroban-client-golang/blend/types"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// Pool event names, the symbol in each event's first topic.
+const (
+	EventSupply             = "supply"
+	EventWithdraw           = "withdraw"
+	EventSupplyCollateral   = "supply_collateral"
+	EventWithdrawCollateral = "withdraw_collateral"
+	EventBorrow             = "borrow"
+	EventRepay              = "repay"
+)
+
+// PositionEvent is emitted when a user changes a position in a pool. Tokens
+// is the change in bTokens for supply and withdraw events and in dTokens for
+// borrow and repay events. Amounts are in the asset's own decimals, so they
+// are kept unscaled.
+type PositionEvent struct {
+	Record  executor.EventRecord
+	Action  string
+	Asset   string
+	From    string
+	Amount  *big.Int
+	BTokens *big.Int
+	DTokens *big.Int
+}
+
+// RegisterPoolEvents registers decoders for the position events of
+// poolContract. An empty poolContract decodes them from any contract.
+func RegisterPoolEvents(registry *executor.EventRegistry, poolContract string) {
+	for _, name := range []string{
+		EventSupply,
+		EventWithdraw,
+		EventSupplyCollateral,
+		EventWithdrawCollateral,
+		EventBorrow,
+		EventRepay,
+	} {
+		executor.RegisterEventDecoder(registry, poolContract, name, DecodePositionEvent)
+	}
+}
+
+func DecodePositionEvent(record executor.EventRecord) (*PositionEvent, error) {
+	action, ok := record.Name()
+	if !ok {
+		return nil, fmt.Errorf("event %s has no name", record.ID)
+	}
+
+	asset, err := types.EventTopicAddress(record, 1)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.EventTopicAddress(record, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := types.EventDataVec(record, 2)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := data[0].GetI128()
+	if !ok {
+		return nil, fmt.Errorf("expected i128 amount in %s event %s", action, record.ID)
+	}
+	tokens, ok := data[1].GetI128()
+	if !ok {
+		return nil, fmt.Errorf("expected i128 token amount in %s event %s", action, record.ID)
+	}
+
+	event := &PositionEvent{
+		Record: record,
+		Action: action,
+		Asset:  asset,
+		From:   from,
+		Amount: helpers.I128ToBigInt(amount),
+	}
+	switch action {
+	case EventBorrow, EventRepay:
+		event.DTokens = helpers.I128ToBigInt(tokens)
+	default:
+		event.BTokens = helpers.I128ToBigInt(tokens)
+	}
+	return event, nil
+}
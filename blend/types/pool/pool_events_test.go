@@ -0,0 +1,44 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/blend/types/internal/eventtest"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
+)
+
+func TestDecodePositionEvent(t *testing.T) {
+	large := eventtest.Large()
+
+	supply, err := DecodePositionEvent(eventtest.Record(EventSupplyCollateral, "["+large.String()+"i128, 90i128]"))
+	require.NoError(t, err)
+	assert.Equal(t, EventSupplyCollateral, supply.Action)
+	assert.Equal(t, eventtest.Contract, supply.Asset)
+	assert.Equal(t, eventtest.User, supply.From)
+	assert.Equal(t, large, supply.Amount)
+	assert.Equal(t, big.NewInt(90), supply.BTokens)
+	assert.Nil(t, supply.DTokens)
+
+	borrow, err := DecodePositionEvent(eventtest.Record(EventBorrow, "[100i128, 95i128]"))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), borrow.Amount)
+	assert.Equal(t, big.NewInt(95), borrow.DTokens)
+	assert.Nil(t, borrow.BTokens)
+}
+
+func TestDecodePositionEventErrors(t *testing.T) {
+	_, err := DecodePositionEvent(eventtest.Record(EventRepay, "100i128"))
+	assert.Error(t, err)
+
+	_, err = DecodePositionEvent(eventtest.Record(EventRepay, "[100i128, 1u32]"))
+	assert.ErrorContains(t, err, "token amount")
+
+	record := eventtest.Record(EventRepay, "[1i128, 1i128]")
+	record.Topics[1] = scv.MustParse("1u32")
+	_, err = DecodePositionEvent(record)
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+// Command soroban-bindgen generates a typed Go client for a Soroban contract
+// from its spec.
+//
+// The spec is read from one of:
+//
+//	soroban-bindgen -wasm pool.wasm -package pool
+//	soroban-bindgen -spec contractspecv0.bin -package pool
+//	soroban-bindgen -rpc https://soroban-testnet.stellar.org -contract C... -package pool
+//
+// where -spec is a contractspecv0 custom section already extracted, and
+// -contract fetches the WASM of a deployed contract through its contract code
+// ledger entry. The bindings are written to -out, or stdout.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/contractspec"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+)
+
+func main() {
+	wasmPath := flag.String("wasm", "", "contract WASM file")
+	specPath := flag.String("spec", "", "contractspecv0 section file")
+	rpcURL := flag.String("rpc", "", "RPC URL to fetch -contract from")
+	contractID := flag.String("contract", "", "deployed contract to fetch the spec of")
+	pkg := flag.String("package", "", "package name of the generated file")
+	out := flag.String("out", "", "output file, stdout when empty")
+	flag.Parse()
+
+	if err := run(*wasmPath, *specPath, *rpcURL, *contractID, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "soroban-bindgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(wasmPath, specPath, rpcURL, contractID, pkg, out string) error {
+	if pkg == "" {
+		return errors.New("-package is required")
+	}
+
+	spec, source, err := loadSpec(wasmPath, specPath, rpcURL, contractID)
+	if err != nil {
+		return err
+	}
+
+	code, err := contractspec.Generate(spec, contractspec.GenerateOptions{Package: pkg, Source: source})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(out, code, 0o644)
+}
+
+func loadSpec(wasmPath, specPath, rpcURL, contractID string) (*contractspec.Spec, string, error) {
+	switch {
+	case wasmPath != "" && specPath == "" && contractID == "":
+		wasm, err := os.ReadFile(wasmPath)
+		if err != nil {
+			return nil, "", err
+		}
+		spec, err := contractspec.ParseWasm(wasm)
+		return spec, filepath.Base(wasmPath), err
+
+	case specPath != "" && wasmPath == "" && contractID == "":
+		data, err := os.ReadFile(specPath)
+		if err != nil {
+			return nil, "", err
+		}
+		spec, err := contractspec.ParseSpec(data)
+		return spec, filepath.Base(specPath), err
+
+	case contractID != "" && wasmPath == "" && specPath == "":
+		if rpcURL == "" {
+			return nil, "", errors.New("-contract needs -rpc")
+		}
+		rpc := soroban.NewClient(rpcURL, nil)
+		defer rpc.Close()
+		spec, err := contractspec.FetchSpecContext(context.Background(), rpc, contractID)
+		return spec, contractID, err
+
+	default:
+		return nil, "", errors.New("give exactly one of -wasm, -spec or -contract")
+	}
+}
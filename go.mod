@@ -4,6 +4,8 @@ go 1.24.2
 
 require (
 	github.com/creachadair/jrpc2 v1.3.2
+	github.com/klauspost/compress v1.17.6
+	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/stellar/go v0.0.0-20250903085211-00c0b06cd7cc
 	github.com/stretchr/testify v1.11.1
 )
@@ -11,7 +13,6 @@ require (
 require (
 	github.com/creachadair/mds v0.25.1 // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
-	github.com/klauspost/compress v1.17.6 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/stellar/go-xdr v0.0.0-20231122183749-b53fb00bcac2 // indirect
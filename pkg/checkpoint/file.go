@@ -0,0 +1,92 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore keeps cursors in a JSON file mapping names to cursors. Every
+// save rewrites the file through a temporary file and a rename, so a crash
+// leaves either the old or the new contents behind.
+type FileStore struct {
+	path string
+	mx   sync.Mutex
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(_ context.Context, name string) (string, bool, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return "", false, err
+	}
+	cursor, ok := cursors[name]
+	return cursor, ok, nil
+}
+
+func (s *FileStore) Save(_ context.Context, name string, cursor string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	cursors, err := s.read()
+	if err != nil {
+		return err
+	}
+	cursors[name] = cursor
+
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("error creating cursor file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing cursor file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing cursor file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing cursor file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("error replacing cursor file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) read() (map[string]string, error) {
+	cursors := make(map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading cursor file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("error decoding cursor file %s: %w", s.path, err)
+	}
+	return cursors, nil
+}
@@ -0,0 +1,31 @@
+package checkpoint
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps cursors in memory. It does not survive restarts and is
+// meant for tests and short-lived processes.
+type MemoryStore struct {
+	mx      sync.RWMutex
+	cursors map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{cursors: make(map[string]string)}
+}
+
+func (s *MemoryStore) Load(_ context.Context, name string) (string, bool, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	cursor, ok := s.cursors[name]
+	return cursor, ok, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, name string, cursor string) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.cursors[name] = cursor
+	return nil
+}
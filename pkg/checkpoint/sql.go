@@ -0,0 +1,76 @@
+package checkpoint
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const DefaultSQLTable = "stream_cursors"
+
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLStore keeps cursors in a database table through database/sql. The
+// queries use ? placeholders and ON CONFLICT upserts, as understood by SQLite.
+// The caller opens the database with a driver of their choice and owns it.
+type SQLStore struct {
+	db        *sql.DB
+	loadQuery string
+	saveQuery string
+}
+
+// NewSQLStore creates table if it does not exist yet and returns a store
+// backed by it. An empty table name uses DefaultSQLTable.
+func NewSQLStore(ctx context.Context, db *sql.DB, table string) (*SQLStore, error) {
+	if table == "" {
+		table = DefaultSQLTable
+	}
+	if !tableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid cursor table name %q", table)
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+			name TEXT PRIMARY KEY,
+			cursor TEXT NOT NULL,
+			updated_at INTEGER NOT NULL
+		)`,
+		table,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error creating cursor table %s: %w", table, err)
+	}
+
+	return &SQLStore{
+		db:        db,
+		loadQuery: fmt.Sprintf(`SELECT cursor FROM %s WHERE name = ?`, table),
+		saveQuery: fmt.Sprintf(
+			`INSERT INTO %s (name, cursor, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET cursor = excluded.cursor, updated_at = excluded.updated_at`,
+			table,
+		),
+	}, nil
+}
+
+func (s *SQLStore) Load(ctx context.Context, name string) (string, bool, error) {
+	var cursor string
+	err := s.db.QueryRowContext(ctx, s.loadQuery, name).Scan(&cursor)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error loading cursor %s: %w", name, err)
+	}
+	return cursor, true, nil
+}
+
+func (s *SQLStore) Save(ctx context.Context, name string, cursor string) error {
+	_, err := s.db.ExecContext(ctx, s.saveQuery, name, cursor, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("error saving cursor %s: %w", name, err)
+	}
+	return nil
+}
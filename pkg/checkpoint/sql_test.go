@@ -0,0 +1,38 @@
+//go:build cgo
+
+// The SQLite driver needs cgo.
+
+package checkpoint
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLStore(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "cursors.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	store, err := NewSQLStore(ctx, db, "")
+	require.NoError(t, err)
+	testStore(t, store)
+
+	// Creating the store again keeps the existing table and its cursors.
+	store, err = NewSQLStore(ctx, db, DefaultSQLTable)
+	require.NoError(t, err)
+	raw, ok, err := store.Load(ctx, "ledgers")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "newer", raw)
+
+	_, err = NewSQLStore(ctx, db, "cursors; DROP TABLE stream_cursors")
+	assert.ErrorContains(t, err, "invalid cursor table name")
+}
@@ -0,0 +1,39 @@
+// Package checkpoint persists the position of event and ledger streams so
+// that indexers can resume where they left off after a restart.
+package checkpoint
+
+import (
+	"context"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// CursorStore saves stream positions under a name, one per stream. Cursors
+// are stored as the opaque strings the RPC hands out, so the same store works
+// for getEvents, getLedgers and getTransactions cursors.
+type CursorStore interface {
+	// Load returns the cursor saved under name, and false if there is none.
+	Load(ctx context.Context, name string) (string, bool, error)
+	// Save replaces the cursor saved under name.
+	Save(ctx context.Context, name string, cursor string) error
+}
+
+// LoadEventCursor loads and parses an event cursor saved under name. It
+// returns nil if none has been saved yet.
+func LoadEventCursor(ctx context.Context, store CursorStore, name string) (*protocol.Cursor, error) {
+	saved, ok, err := store.Load(ctx, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	cursor, err := protocol.ParseCursor(saved)
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// SaveEventCursor saves an event cursor under name.
+func SaveEventCursor(ctx context.Context, store CursorStore, name string, cursor protocol.Cursor) error {
+	return store.Save(ctx, name, cursor.String())
+}
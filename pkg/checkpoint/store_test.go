@@ -0,0 +1,54 @@
+package checkpoint
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func testStore(t *testing.T, store CursorStore) {
+	ctx := context.Background()
+
+	cursor, err := LoadEventCursor(ctx, store, "events")
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+
+	saved := protocol.Cursor{Ledger: 1234, Tx: 5, Op: 1, Event: 2}
+	require.NoError(t, SaveEventCursor(ctx, store, "events", saved))
+	require.NoError(t, store.Save(ctx, "ledgers", "opaque"))
+
+	cursor, err = LoadEventCursor(ctx, store, "events")
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.Equal(t, saved, *cursor)
+
+	raw, ok, err := store.Load(ctx, "ledgers")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "opaque", raw)
+
+	require.NoError(t, store.Save(ctx, "ledgers", "newer"))
+	raw, _, err = store.Load(ctx, "ledgers")
+	require.NoError(t, err)
+	assert.Equal(t, "newer", raw)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+	testStore(t, NewFileStore(path))
+
+	// A fresh store over the same file sees what the first one saved.
+	raw, ok, err := NewFileStore(path).Load(context.Background(), "ledgers")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "newer", raw)
+}
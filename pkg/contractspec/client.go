@@ -0,0 +1,154 @@
+package contractspec
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+)
+
+// Client is the runtime behind generated bindings: the contract they call
+// and how calls are signed and submitted.
+type Client struct {
+	RPC               *soroban.RpcClient
+	ContractID        string
+	Address           xdr.ScAddress
+	NetworkPassphrase string
+	// Signers sign submitted transactions. Simulation needs none.
+	Signers       []*keypair.Full
+	SubmitOptions executor.SubmitOptions
+}
+
+func NewClient(
+	rpc *soroban.RpcClient,
+	contractID string,
+	networkPassphrase string,
+	signers ...*keypair.Full,
+) (*Client, error) {
+	address, err := helpers.ContractAddressToScAddress(contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		RPC:               rpc,
+		ContractID:        contractID,
+		Address:           address,
+		NetworkPassphrase: networkPassphrase,
+		Signers:           signers,
+		SubmitOptions:     executor.DefaultSubmitOptions(),
+	}, nil
+}
+
+// Simulate calls function without submitting a transaction and returns its
+// result.
+func (c *Client) Simulate(
+	ctx context.Context,
+	source txnbuild.Account,
+	function string,
+	args xdr.ScVec,
+) (xdr.ScVal, error) {
+	result, err := executor.SimulateContractCallContext(ctx, c.RPC, c.Address, source, args, xdr.ScSymbol(function))
+	if err != nil {
+		return xdr.ScVal{}, fmt.Errorf("%s: %w", function, err)
+	}
+	return *result, nil
+}
+
+// Invoke submits a call to function and waits for it to land.
+func (c *Client) Invoke(
+	ctx context.Context,
+	source txnbuild.Account,
+	function string,
+	args xdr.ScVec,
+) (*executor.TransactionResult, error) {
+	result, err := executor.InvokeContractCall(
+		ctx,
+		c.RPC,
+		c.Address,
+		source,
+		args,
+		xdr.ScSymbol(function),
+		c.NetworkPassphrase,
+		c.Signers,
+		c.SubmitOptions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", function, err)
+	}
+	return result, nil
+}
+
+// DecodeResult decodes function's return value into out. A missing value,
+// as from a transaction without one, leaves out unchanged.
+func DecodeResult(function string, val *xdr.ScVal, out any) error {
+	if val == nil {
+		return nil
+	}
+	if err := helpers.UnmarshalScVal(*val, out); err != nil {
+		return fmt.Errorf("error decoding result of %s: %w", function, err)
+	}
+	return nil
+}
+
+// MarshalUnion encodes a union variant the way contracttype enums are: a vec
+// of the variant name followed by its values. values is nil for variants
+// without values, and otherwise a struct whose fields are the values in
+// order.
+func MarshalUnion(variant string, values any) (xdr.ScVal, error) {
+	vec := xdr.ScVec{{}}
+	var err error
+	if vec[0], err = xdr.NewScVal(xdr.ScValTypeScvSymbol, xdr.ScSymbol(variant)); err != nil {
+		return xdr.ScVal{}, err
+	}
+
+	if values != nil {
+		if v := reflect.ValueOf(values); v.Kind() == reflect.Pointer && v.IsNil() {
+			return xdr.ScVal{}, fmt.Errorf("variant %s has no values set", variant)
+		}
+		items, err := helpers.MarshalScValTuple(values)
+		if err != nil {
+			return xdr.ScVal{}, fmt.Errorf("variant %s: %w", variant, err)
+		}
+		vec = append(vec, items...)
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvVec, &vec)
+}
+
+// UnmarshalUnion splits an encoded union into its variant name and values.
+func UnmarshalUnion(val xdr.ScVal) (string, xdr.ScVec, error) {
+	vec, ok := val.GetVec()
+	if !ok || vec == nil || len(*vec) == 0 {
+		return "", nil, fmt.Errorf("expected a union variant, got %s", val.Type)
+	}
+	variant, ok := (*vec)[0].GetSym()
+	if !ok {
+		return "", nil, fmt.Errorf("expected a variant symbol, got %s", (*vec)[0].Type)
+	}
+	return string(variant), (*vec)[1:], nil
+}
+
+// MarshalTuple encodes the fields of a tuple struct as a vec.
+func MarshalTuple(values any) (xdr.ScVal, error) {
+	vec, err := helpers.MarshalScValTuple(values)
+	if err != nil {
+		return xdr.ScVal{}, err
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvVec, &vec)
+}
+
+// UnmarshalTuple decodes a vec into the fields of the tuple struct out
+// points to.
+func UnmarshalTuple(val xdr.ScVal, out any) error {
+	vec, ok := val.GetVec()
+	if !ok || vec == nil {
+		return fmt.Errorf("expected a tuple, got %s", val.Type)
+	}
+	return helpers.UnmarshalScValTuple(*vec, out)
+}
@@ -0,0 +1,85 @@
+package contractspec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+)
+
+// ErrNotWasm is returned for contracts without WASM, such as Stellar asset
+// contracts.
+var ErrNotWasm = errors.New("contract is not a wasm contract")
+
+func FetchWasm(rpc *soroban.RpcClient, contractID string) ([]byte, error) {
+	return FetchWasmContext(context.Background(), rpc, contractID)
+}
+
+// FetchWasmContext reads a deployed contract's WASM: its instance gives the
+// WASM hash, which keys the contract code ledger entry.
+func FetchWasmContext(ctx context.Context, rpc *soroban.RpcClient, contractID string) ([]byte, error) {
+	instanceKey, err := scv.InstanceKey(contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceEntry, err := fetchEntry(ctx, rpc, instanceKey)
+	if err != nil {
+		return nil, fmt.Errorf("error loading instance of %s: %w", contractID, err)
+	}
+	contractData, ok := instanceEntry.Data.GetContractData()
+	if !ok {
+		return nil, fmt.Errorf("instance of %s is not contract data", contractID)
+	}
+	instance, ok := contractData.Val.GetInstance()
+	if !ok {
+		return nil, fmt.Errorf("instance of %s is not a contract instance", contractID)
+	}
+	wasmHash, ok := instance.Executable.GetWasmHash()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotWasm, contractID)
+	}
+
+	codeKey := xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: wasmHash},
+	}
+	codeEntry, err := fetchEntry(ctx, rpc, codeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error loading code %x of %s: %w", wasmHash, contractID, err)
+	}
+	code, ok := codeEntry.Data.GetContractCode()
+	if !ok {
+		return nil, fmt.Errorf("code %x of %s is not contract code", wasmHash, contractID)
+	}
+	return code.Code, nil
+}
+
+func FetchSpec(rpc *soroban.RpcClient, contractID string) (*Spec, error) {
+	return FetchSpecContext(context.Background(), rpc, contractID)
+}
+
+// FetchSpecContext reads the spec of a deployed contract from its WASM.
+func FetchSpecContext(ctx context.Context, rpc *soroban.RpcClient, contractID string) (*Spec, error) {
+	wasm, err := FetchWasmContext(ctx, rpc, contractID)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWasm(wasm)
+}
+
+func fetchEntry(ctx context.Context, rpc *soroban.RpcClient, key xdr.LedgerKey) (executor.LedgerEntry, error) {
+	entries, err := executor.LedgerEntriesCall(ctx, rpc, []xdr.LedgerKey{key}, executor.DefaultLedgerEntriesOptions())
+	if err != nil {
+		return executor.LedgerEntry{}, err
+	}
+	entry, ok := entries.Get(key)
+	if !ok {
+		return executor.LedgerEntry{}, errors.New("ledger entry not found")
+	}
+	return entry, nil
+}
@@ -0,0 +1,699 @@
+package contractspec
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stellar/go/xdr"
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// Package is the package name of the generated file.
+	Package string
+	// Source describes where the spec came from, such as a WASM file name,
+	// for the generated file's header.
+	Source string
+}
+
+// Generate writes Go bindings for spec:
+//
+//   - structs become Go structs with soroban tags, or vec encoded tuple
+//     structs when their fields are numbered
+//   - unions become a struct holding the variant Kind and one field per
+//     variant with values
+//   - enums and error enums become uint32 types with constants; error enums
+//     implement error, and As<Name> recovers one from a failed call
+//   - each function becomes a Client method that simulates the call, and an
+//     Invoke method that submits it and waits for the result
+//
+// u128, i256 and u256 values nested in vecs, maps or other containers, and
+// tuples and maps keyed by non-comparable types, are left as xdr.ScVal.
+// Events are not generated.
+func Generate(spec *Spec, opts GenerateOptions) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	g := &generator{
+		spec:     spec,
+		imports:  map[string]bool{},
+		declared: map[string]bool{"Client": true, "NewClient": true},
+		udts:     map[string]xdr.ScSpecEntry{},
+	}
+	for _, entry := range spec.Entries {
+		if name := udtName(entry); name != "" {
+			g.udts[name] = entry
+		}
+	}
+
+	for _, entry := range spec.Entries {
+		var err error
+		switch entry.Kind {
+		case xdr.ScSpecEntryKindScSpecEntryUdtStructV0:
+			err = g.structType(*entry.UdtStructV0)
+		case xdr.ScSpecEntryKindScSpecEntryUdtUnionV0:
+			err = g.unionType(*entry.UdtUnionV0)
+		case xdr.ScSpecEntryKindScSpecEntryUdtEnumV0:
+			err = g.enumType(*entry.UdtEnumV0)
+		case xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0:
+			err = g.errorEnumType(*entry.UdtErrorEnumV0)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := g.client(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by soroban-bindgen. DO NOT EDIT.\n")
+	if opts.Source != "" {
+		fmt.Fprintf(&out, "// Source: %s\n", opts.Source)
+	}
+	fmt.Fprintf(&out, "\npackage %s\n\nimport (\n", opts.Package)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	// Standard library first, as goimports groups them.
+	slices.SortFunc(imports, func(a, b string) int {
+		if stdA, stdB := isStdlib(a), isStdlib(b); stdA != stdB {
+			if stdA {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for idx, path := range imports {
+		if idx > 0 && isStdlib(imports[idx-1]) && !isStdlib(path) {
+			out.WriteString("\n")
+		}
+		if path == rpcImport {
+			fmt.Fprintf(&out, "\tsoroban %q\n", path)
+			continue
+		}
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	out.WriteString(")\n")
+	out.WriteString(g.body.String())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+const (
+	rpcImport          = "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	executorImport     = "github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	helpersImport      = "github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	contractspecImport = "github.com/tryoutbounder/soroban-client-golang/pkg/contractspec"
+)
+
+func isStdlib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// reservedArgs are the identifiers generated methods use themselves.
+var reservedArgs = map[string]bool{
+	"c": true, "ctx": true, "source": true, "args": true, "val": true,
+	"result": true, "tx": true, "err": true,
+}
+
+type generator struct {
+	spec     *Spec
+	body     strings.Builder
+	imports  map[string]bool
+	declared map[string]bool
+	udts     map[string]xdr.ScSpecEntry
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+func (g *generator) use(path string) {
+	g.imports[path] = true
+}
+
+// declare reserves a top level Go name.
+func (g *generator) declare(name string) error {
+	if g.declared[name] {
+		return fmt.Errorf("generated name %s is used twice", name)
+	}
+	g.declared[name] = true
+	return nil
+}
+
+func (g *generator) doc(doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		g.printf("// %s\n", strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+// goType returns the Go type for def and the soroban tag type option it
+// needs, if any. Nested types sit inside vecs and maps, where tag options do
+// not reach.
+func (g *generator) goType(def xdr.ScSpecTypeDef, nested bool) (string, string, error) {
+	switch def.Type {
+	case xdr.ScSpecTypeScSpecTypeVal, xdr.ScSpecTypeScSpecTypeError, xdr.ScSpecTypeScSpecTypeVoid:
+		g.use("github.com/stellar/go/xdr")
+		return "xdr.ScVal", "", nil
+	case xdr.ScSpecTypeScSpecTypeBool:
+		return "bool", "", nil
+	case xdr.ScSpecTypeScSpecTypeU32:
+		return "uint32", "", nil
+	case xdr.ScSpecTypeScSpecTypeI32:
+		return "int32", "", nil
+	case xdr.ScSpecTypeScSpecTypeU64:
+		return "uint64", "", nil
+	case xdr.ScSpecTypeScSpecTypeI64:
+		return "int64", "", nil
+	case xdr.ScSpecTypeScSpecTypeTimepoint:
+		g.use("time")
+		return "time.Time", "", nil
+	case xdr.ScSpecTypeScSpecTypeDuration:
+		g.use("time")
+		return "time.Duration", "", nil
+	case xdr.ScSpecTypeScSpecTypeI128:
+		g.use("math/big")
+		return "*big.Int", "", nil
+	case xdr.ScSpecTypeScSpecTypeU128, xdr.ScSpecTypeScSpecTypeI256, xdr.ScSpecTypeScSpecTypeU256:
+		if nested {
+			g.use("github.com/stellar/go/xdr")
+			return "xdr.ScVal", "", nil
+		}
+		g.use("math/big")
+		return "*big.Int", strings.TrimPrefix(strings.ToLower(def.Type.String()), "scspectypescspectype"), nil
+	case xdr.ScSpecTypeScSpecTypeBytes:
+		return "[]byte", "", nil
+	case xdr.ScSpecTypeScSpecTypeBytesN:
+		return fmt.Sprintf("[%d]byte", def.BytesN.N), "", nil
+	case xdr.ScSpecTypeScSpecTypeString:
+		return "string", "", nil
+	case xdr.ScSpecTypeScSpecTypeSymbol:
+		g.use(helpersImport)
+		return "helpers.Symbol", "", nil
+	case xdr.ScSpecTypeScSpecTypeAddress, xdr.ScSpecTypeScSpecTypeMuxedAddress:
+		g.use(helpersImport)
+		return "helpers.Address", "", nil
+
+	case xdr.ScSpecTypeScSpecTypeOption:
+		inner, kind, err := g.goType(def.Option.ValueType, nested)
+		if err != nil {
+			return "", "", err
+		}
+		if strings.HasPrefix(inner, "*") || inner == "xdr.ScVal" {
+			return inner, kind, nil
+		}
+		return "*" + inner, kind, nil
+	case xdr.ScSpecTypeScSpecTypeResult:
+		return g.goType(def.Result.OkType, nested)
+	case xdr.ScSpecTypeScSpecTypeVec:
+		elem, _, err := g.goType(def.Vec.ElementType, true)
+		if err != nil {
+			return "", "", err
+		}
+		return "[]" + elem, "", nil
+	case xdr.ScSpecTypeScSpecTypeMap:
+		key, _, err := g.goType(def.Map.KeyType, true)
+		if err != nil {
+			return "", "", err
+		}
+		val, _, err := g.goType(def.Map.ValueType, true)
+		if err != nil {
+			return "", "", err
+		}
+		if !g.comparable(key) {
+			g.use("github.com/stellar/go/xdr")
+			return "xdr.ScVal", "", nil
+		}
+		return fmt.Sprintf("map[%s]%s", key, val), "", nil
+	case xdr.ScSpecTypeScSpecTypeTuple:
+		g.use("github.com/stellar/go/xdr")
+		return "[]xdr.ScVal", "", nil
+	case xdr.ScSpecTypeScSpecTypeUdt:
+		if _, ok := g.udts[def.Udt.Name]; !ok {
+			return "", "", fmt.Errorf("unknown type %s", def.Udt.Name)
+		}
+		return exportedName(def.Udt.Name), "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported spec type %s", def.Type)
+	}
+}
+
+// comparable reports whether a Go type generated by goType can be a map key
+// that compares by value.
+func (g *generator) comparable(goType string) bool {
+	switch goType {
+	case "bool", "uint32", "int32", "uint64", "int64", "string", "helpers.Symbol", "helpers.Address":
+		return true
+	}
+	if strings.HasPrefix(goType, "[") && strings.HasSuffix(goType, "]byte") && goType != "[]byte" {
+		return true
+	}
+	for name, entry := range g.udts {
+		if exportedName(name) != goType {
+			continue
+		}
+		return entry.Kind == xdr.ScSpecEntryKindScSpecEntryUdtEnumV0 ||
+			entry.Kind == xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0
+	}
+	return false
+}
+
+// tag returns a struct tag for a field called name with type option kind.
+func tag(name, kind string) string {
+	value := name
+	if kind != "" {
+		value += "," + kind
+	}
+	if value == "" {
+		return ""
+	}
+	return fmt.Sprintf(" `soroban:%q`", value)
+}
+
+// tupleFields writes the fields V0, V1... of a vec encoded struct.
+func (g *generator) tupleFields(types []xdr.ScSpecTypeDef) error {
+	for idx, def := range types {
+		goType, kind, err := g.goType(def, false)
+		if err != nil {
+			return err
+		}
+		g.printf("\tV%d %s%s\n", idx, goType, tag("", kind))
+	}
+	return nil
+}
+
+func (g *generator) structType(s xdr.ScSpecUdtStructV0) error {
+	name := exportedName(s.Name)
+	if err := g.declare(name); err != nil {
+		return err
+	}
+
+	tuple := len(s.Fields) > 0
+	for idx, field := range s.Fields {
+		tuple = tuple && field.Name == strconv.Itoa(idx)
+	}
+
+	g.printf("\n")
+	g.doc(s.Doc)
+	g.printf("type %s struct {\n", name)
+	if tuple {
+		types := make([]xdr.ScSpecTypeDef, len(s.Fields))
+		for idx, field := range s.Fields {
+			types[idx] = field.Type
+		}
+		if err := g.tupleFields(types); err != nil {
+			return fmt.Errorf("struct %s: %w", s.Name, err)
+		}
+		g.printf("}\n")
+
+		g.use("github.com/stellar/go/xdr")
+		g.use(contractspecImport)
+		g.printf("\nfunc (s %s) MarshalScVal() (xdr.ScVal, error) {\n\treturn contractspec.MarshalTuple(s)\n}\n", name)
+		g.printf("\nfunc (s *%s) UnmarshalScVal(val xdr.ScVal) error {\n\treturn contractspec.UnmarshalTuple(val, s)\n}\n", name)
+		return nil
+	}
+
+	fields := map[string]bool{}
+	for _, field := range s.Fields {
+		goType, kind, err := g.goType(field.Type, false)
+		if err != nil {
+			return fmt.Errorf("struct %s field %s: %w", s.Name, field.Name, err)
+		}
+		fieldName := exportedName(field.Name)
+		if fields[fieldName] {
+			return fmt.Errorf("struct %s has two fields named %s", s.Name, fieldName)
+		}
+		fields[fieldName] = true
+
+		g.doc(field.Doc)
+		g.printf("\t%s %s%s\n", fieldName, goType, tag(field.Name, kind))
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) unionType(u xdr.ScSpecUdtUnionV0) error {
+	name := exportedName(u.Name)
+	kindType := name + "Kind"
+	for _, declared := range []string{name, kindType} {
+		if err := g.declare(declared); err != nil {
+			return err
+		}
+	}
+
+	type variant struct {
+		name, constant, payload string
+		types                   []xdr.ScSpecTypeDef
+	}
+	variants := make([]variant, len(u.Cases))
+	for idx, unionCase := range u.Cases {
+		var v variant
+		switch unionCase.Kind {
+		case xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseVoidV0:
+			v.name = unionCase.VoidCase.Name
+		case xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0:
+			v.name = unionCase.TupleCase.Name
+			v.types = unionCase.TupleCase.Type
+			if len(v.types) > 0 {
+				v.payload = name + exportedName(v.name)
+				if err := g.declare(v.payload); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("union %s: unsupported case kind %d", u.Name, unionCase.Kind)
+		}
+		v.constant = kindType + exportedName(v.name)
+		if err := g.declare(v.constant); err != nil {
+			return err
+		}
+		variants[idx] = v
+	}
+
+	g.printf("\n// %s names a variant of %s.\ntype %s string\n\nconst (\n", kindType, name, kindType)
+	for _, v := range variants {
+		g.printf("\t%s %s = %q\n", v.constant, kindType, v.name)
+	}
+	g.printf(")\n\n")
+
+	g.printf("// %s is a union: Kind selects the variant, and the field of the same\n// name holds its values.\n", name)
+	if strings.TrimSpace(u.Doc) != "" {
+		g.printf("//\n")
+		g.doc(u.Doc)
+	}
+	g.printf("type %s struct {\n\tKind %s\n", name, kindType)
+	for _, v := range variants {
+		if v.payload != "" {
+			g.printf("\t%s *%s\n", exportedName(v.name), v.payload)
+		}
+	}
+	g.printf("}\n")
+
+	for idx, v := range variants {
+		if v.payload == "" {
+			continue
+		}
+		g.printf("\n")
+		g.doc(unionCaseDoc(u.Cases[idx]))
+		g.printf("type %s struct {\n", v.payload)
+		if err := g.tupleFields(v.types); err != nil {
+			return fmt.Errorf("union %s case %s: %w", u.Name, v.name, err)
+		}
+		g.printf("}\n")
+	}
+
+	g.use("fmt")
+	g.use("github.com/stellar/go/xdr")
+	g.use(helpersImport)
+	g.use(contractspecImport)
+
+	g.printf("\nfunc (u %s) MarshalScVal() (xdr.ScVal, error) {\n\tswitch u.Kind {\n", name)
+	for _, v := range variants {
+		values := "nil"
+		if v.payload != "" {
+			values = "u." + exportedName(v.name)
+		}
+		g.printf("\tcase %s:\n\t\treturn contractspec.MarshalUnion(string(u.Kind), %s)\n", v.constant, values)
+	}
+	g.printf("\t}\n\treturn xdr.ScVal{}, fmt.Errorf(\"unknown %s variant %%q\", u.Kind)\n}\n", name)
+
+	g.printf("\nfunc (u *%s) UnmarshalScVal(val xdr.ScVal) error {\n", name)
+	g.printf("\tkind, values, err := contractspec.UnmarshalUnion(val)\n\tif err != nil {\n\t\treturn err\n\t}\n")
+	g.printf("\t*u = %s{Kind: %s(kind)}\n\tswitch u.Kind {\n", name, kindType)
+	for _, v := range variants {
+		g.printf("\tcase %s:\n", v.constant)
+		if v.payload == "" {
+			g.printf("\t\treturn helpers.UnmarshalScValTuple(values, &struct{}{})\n")
+			continue
+		}
+		field := "u." + exportedName(v.name)
+		g.printf("\t\t%s = &%s{}\n\t\treturn helpers.UnmarshalScValTuple(values, %s)\n", field, v.payload, field)
+	}
+	g.printf("\t}\n\treturn fmt.Errorf(\"unknown %s variant %%q\", kind)\n}\n", name)
+	return nil
+}
+
+func unionCaseDoc(unionCase xdr.ScSpecUdtUnionCaseV0) string {
+	if unionCase.TupleCase != nil {
+		return unionCase.TupleCase.Doc
+	}
+	if unionCase.VoidCase != nil {
+		return unionCase.VoidCase.Doc
+	}
+	return ""
+}
+
+type enumCase struct {
+	doc, name string
+	value     uint32
+}
+
+// enumConstants declares the type and constants shared by enums and error
+// enums.
+func (g *generator) enumConstants(name, doc string, cases []enumCase) error {
+	if err := g.declare(name); err != nil {
+		return err
+	}
+
+	g.printf("\n")
+	g.doc(doc)
+	g.printf("type %s uint32\n\nconst (\n", name)
+	for _, c := range cases {
+		constant := name + exportedName(c.name)
+		if err := g.declare(constant); err != nil {
+			return err
+		}
+		g.doc(c.doc)
+		g.printf("\t%s %s = %d\n", constant, name, c.value)
+	}
+	g.printf(")\n")
+	return nil
+}
+
+func (g *generator) enumType(e xdr.ScSpecUdtEnumV0) error {
+	cases := make([]enumCase, len(e.Cases))
+	for idx, c := range e.Cases {
+		cases[idx] = enumCase{doc: c.Doc, name: c.Name, value: uint32(c.Value)}
+	}
+	return g.enumConstants(exportedName(e.Name), e.Doc, cases)
+}
+
+func (g *generator) errorEnumType(e xdr.ScSpecUdtErrorEnumV0) error {
+	name := exportedName(e.Name)
+	cases := make([]enumCase, len(e.Cases))
+	for idx, c := range e.Cases {
+		cases[idx] = enumCase{doc: c.Doc, name: c.Name, value: uint32(c.Value)}
+	}
+	if err := g.enumConstants(name, e.Doc, cases); err != nil {
+		return err
+	}
+
+	names := unexportedName(e.Name) + "Names"
+	as := "As" + name
+	for _, declared := range []string{names, as} {
+		if err := g.declare(declared); err != nil {
+			return err
+		}
+	}
+
+	g.use("fmt")
+	g.use("errors")
+	g.use(executorImport)
+
+	g.printf("\nvar %s = map[%s]string{\n", names, name)
+	for _, c := range cases {
+		g.printf("\t%s%s: %q,\n", name, exportedName(c.name), c.name)
+	}
+	g.printf("}\n")
+
+	g.printf("\nfunc (e %s) Error() string {\n", name)
+	g.printf("\tif caseName, ok := %s[e]; ok {\n\t\treturn fmt.Sprintf(\"%s %%s (#%%d)\", caseName, uint32(e))\n\t}\n", names, e.Name)
+	g.printf("\treturn fmt.Sprintf(\"%s #%%d\", uint32(e))\n}\n", e.Name)
+
+	g.printf("\n// %s returns the %s a failed call carries, if it is one of its codes.\n", as, name)
+	g.printf("func %s(err error) (%s, bool) {\n", as, name)
+	g.printf("\tvar contractErr *executor.ContractError\n\tif !errors.As(err, &contractErr) {\n\t\treturn 0, false\n\t}\n")
+	g.printf("\tcode := %s(contractErr.Code)\n\t_, ok := %s[code]\n\treturn code, ok\n}\n", name, names)
+	return nil
+}
+
+func (g *generator) client() error {
+	g.use("github.com/stellar/go/keypair")
+	g.use(rpcImport)
+	g.use(contractspecImport)
+
+	g.printf(`
+// Client calls the contract. Runtime holds the RPC client, contract ID,
+// signers and submit options.
+type Client struct {
+	Runtime *contractspec.Client
+}
+
+func NewClient(
+	rpc *soroban.RpcClient,
+	contractID string,
+	networkPassphrase string,
+	signers ...*keypair.Full,
+) (*Client, error) {
+	runtime, err := contractspec.NewClient(rpc, contractID, networkPassphrase, signers...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Runtime: runtime}, nil
+}
+`)
+
+	methods := map[string]bool{"Runtime": true}
+	for _, function := range g.spec.Functions() {
+		if err := g.function(function, methods); err != nil {
+			return fmt.Errorf("function %s: %w", function.Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) function(function xdr.ScSpecFunctionV0, methods map[string]bool) error {
+	name := string(function.Name)
+	method := exportedName(name)
+	invoke := "Invoke" + method
+	argsFunc := unexportedName(name) + "Args"
+	for _, declared := range []string{method, invoke} {
+		if methods[declared] {
+			return fmt.Errorf("method %s is generated twice", declared)
+		}
+		methods[declared] = true
+	}
+	if err := g.declare(argsFunc); err != nil {
+		return err
+	}
+
+	var params, fields, values []string
+	argFields := map[string]bool{}
+	for _, input := range function.Inputs {
+		goType, kind, err := g.goType(input.Type, false)
+		if err != nil {
+			return fmt.Errorf("argument %s: %w", input.Name, err)
+		}
+		arg := unexportedName(input.Name)
+		if token.IsKeyword(arg) || reservedArgs[arg] {
+			arg += "Arg"
+		}
+		field := exportedName(input.Name)
+		if argFields[field] {
+			return fmt.Errorf("two arguments named %s", field)
+		}
+		argFields[field] = true
+
+		params = append(params, fmt.Sprintf("%s %s", arg, goType))
+		fields = append(fields, fmt.Sprintf("\t\t%s %s%s\n", field, goType, tag("", kind)))
+		values = append(values, arg)
+	}
+
+	resultType := ""
+	if len(function.Outputs) > 0 && function.Outputs[0].Type != xdr.ScSpecTypeScSpecTypeVoid {
+		output := function.Outputs[0]
+		if output.Type == xdr.ScSpecTypeScSpecTypeResult && output.Result.OkType.Type == xdr.ScSpecTypeScSpecTypeVoid {
+			output = output.Result.OkType
+		}
+		if output.Type != xdr.ScSpecTypeScSpecTypeVoid {
+			var err error
+			if resultType, _, err = g.goType(output, false); err != nil {
+				return fmt.Errorf("result: %w", err)
+			}
+		}
+	}
+
+	g.use("context")
+	g.use("fmt")
+	g.use("github.com/stellar/go/txnbuild")
+	g.use("github.com/stellar/go/xdr")
+	g.use(executorImport)
+	g.use(helpersImport)
+	g.use(contractspecImport)
+
+	paramList := strings.Join(append([]string{"ctx context.Context", "source txnbuild.Account"}, params...), ", ")
+	argList := strings.Join(values, ", ")
+
+	g.printf("\nfunc %s(%s) (xdr.ScVec, error) {\n", argsFunc, strings.Join(params, ", "))
+	if len(fields) == 0 {
+		g.printf("\targs, err := helpers.MarshalScValTuple(struct{}{})\n")
+	} else {
+		g.printf("\targs, err := helpers.MarshalScValTuple(struct {\n%s\t}{%s})\n", strings.Join(fields, ""), argList)
+	}
+	g.printf("\tif err != nil {\n\t\treturn nil, fmt.Errorf(\"%s: %%w\", err)\n\t}\n\treturn args, nil\n}\n", name)
+
+	g.printf("\n// %s simulates %s without submitting it.\n", method, name)
+	if strings.TrimSpace(function.Doc) != "" {
+		g.printf("//\n")
+		g.doc(function.Doc)
+	}
+	if resultType == "" {
+		g.printf("func (c *Client) %s(%s) error {\n", method, paramList)
+		g.printf("\targs, err := %s(%s)\n\tif err != nil {\n\t\treturn err\n\t}\n", argsFunc, argList)
+		g.printf("\t_, err = c.Runtime.Simulate(ctx, source, %q, args)\n\treturn err\n}\n", name)
+	} else {
+		g.printf("func (c *Client) %s(%s) (result %s, err error) {\n", method, paramList, resultType)
+		g.printf("\targs, err := %s(%s)\n\tif err != nil {\n\t\treturn result, err\n\t}\n", argsFunc, argList)
+		g.printf("\tval, err := c.Runtime.Simulate(ctx, source, %q, args)\n\tif err != nil {\n\t\treturn result, err\n\t}\n", name)
+		g.printf("\terr = contractspec.DecodeResult(%q, &val, &result)\n\treturn result, err\n}\n", name)
+	}
+
+	g.printf("\n// %s submits %s and waits for it to land.\n", invoke, name)
+	if resultType == "" {
+		g.printf("func (c *Client) %s(%s) (*executor.TransactionResult, error) {\n", invoke, paramList)
+		g.printf("\targs, err := %s(%s)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n", argsFunc, argList)
+		g.printf("\treturn c.Runtime.Invoke(ctx, source, %q, args)\n}\n", name)
+	} else {
+		g.printf("func (c *Client) %s(%s) (result %s, tx *executor.TransactionResult, err error) {\n", invoke, paramList, resultType)
+		g.printf("\targs, err := %s(%s)\n\tif err != nil {\n\t\treturn result, nil, err\n\t}\n", argsFunc, argList)
+		g.printf("\ttx, err = c.Runtime.Invoke(ctx, source, %q, args)\n\tif err != nil {\n\t\treturn result, tx, err\n\t}\n", name)
+		g.printf("\terr = contractspec.DecodeResult(%q, tx.ReturnValue, &result)\n\treturn result, tx, err\n}\n", name)
+	}
+	return nil
+}
+
+// exportedName turns a spec name such as user_balance or UserBalance into
+// an exported Go identifier.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	exported := b.String()
+	if exported == "" || unicode.IsDigit([]rune(exported)[0]) {
+		exported = "V" + exported
+	}
+	return exported
+}
+
+// unexportedName is exportedName with a lower case first letter.
+func unexportedName(name string) string {
+	runes := []rune(exportedName(name))
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,95 @@
+package testbindings
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/executor"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers/scv"
+)
+
+const (
+	testPool = "CDLZFC3SYJYDZT7K67VZ75HPJVIEUVNIXF47ZG2FB2RMQQVU2HHGCYSC"
+	testUser = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+)
+
+func TestDataKeyRoundTrip(t *testing.T) {
+	keys := []DataKey{
+		{Kind: DataKeyKindUserBalance, UserBalance: &DataKeyUserBalance{V0: PoolUserKey{Pool: testPool, User: testUser}}},
+		{Kind: DataKeyKindPoolBalance, PoolBalance: &DataKeyPoolBalance{V0: testPool}},
+		{Kind: DataKeyKindRZ},
+	}
+	for _, key := range keys {
+		val, err := helpers.MarshalScVal(key)
+		require.NoError(t, err)
+
+		var decoded DataKey
+		require.NoError(t, helpers.UnmarshalScVal(val, &decoded))
+		assert.Equal(t, key, decoded)
+	}
+
+	val, err := helpers.MarshalScVal(keys[1])
+	require.NoError(t, err)
+	assert.Equal(t, `[PoolBalance, `+testPool+`]`, scv.Format(val))
+
+	_, err = helpers.MarshalScVal(DataKey{Kind: DataKeyKindPoolBalance})
+	assert.Error(t, err)
+}
+
+func TestPairRoundTrip(t *testing.T) {
+	pair := Pair{V0: testPool, V1: big.NewInt(42)}
+	val, err := helpers.MarshalScVal(pair)
+	require.NoError(t, err)
+	assert.Equal(t, `[`+testPool+`, 42u128]`, scv.Format(val))
+
+	var decoded Pair
+	require.NoError(t, helpers.UnmarshalScVal(val, &decoded))
+	assert.Equal(t, pair, decoded)
+}
+
+func TestArgs(t *testing.T) {
+	args, err := depositArgs(testUser, testPool, big.NewInt(-5))
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	assert.Equal(t, xdr.ScValTypeScvAddress, args[0].Type)
+	assert.Equal(t, "-5i128", scv.Format(args[2]))
+
+	args, err = rewardZoneArgs()
+	require.NoError(t, err)
+	assert.Empty(t, args)
+}
+
+func TestArgsNilCollections(t *testing.T) {
+	args, err := setRewardZoneArgs(nil)
+	require.NoError(t, err)
+	require.Len(t, args, 1)
+	require.Equal(t, xdr.ScValTypeScvVec, args[0].Type)
+	assert.Empty(t, **args[0].Vec)
+
+	args, err = setStatusArgs(ReserveStatusFrozen, big.NewInt(1), nil, nil)
+	require.NoError(t, err)
+	require.Len(t, args, 4)
+	require.Equal(t, xdr.ScValTypeScvMap, args[2].Type)
+	assert.Empty(t, **args[2].Map)
+	assert.Equal(t, xdr.ScValTypeScvVoid, args[3].Type)
+
+	args, err = setRewardZoneArgs([]helpers.Address{testPool})
+	require.NoError(t, err)
+	assert.Equal(t, `[`+testPool+`]`, scv.Format(args[0]))
+}
+
+func TestAsBackstopError(t *testing.T) {
+	err := fmt.Errorf("deposit: %w", &executor.ContractError{Code: 1001})
+	code, ok := AsBackstopError(err)
+	assert.True(t, ok)
+	assert.Equal(t, BackstopErrorInsufficientFunds, code)
+	assert.Equal(t, "BackstopError InsufficientFunds (#1001)", code.Error())
+
+	_, ok = AsBackstopError(fmt.Errorf("deposit: %w", &executor.ContractError{Code: 7}))
+	assert.False(t, ok)
+}
@@ -0,0 +1,154 @@
+// Package contractspec reads the interface a Soroban contract embeds in its
+// WASM, and generates typed Go clients from it.
+//
+// Contracts built with the Soroban SDK carry their spec in a contractspecv0
+// custom section: a stream of XDR ScSpecEntry values describing functions,
+// structs, unions, enums, error enums and events. ParseWasm extracts it from
+// a WASM file, ParseSpec decodes a section already extracted, and FetchSpec
+// reads it from the contract code ledger entry of a deployed contract.
+package contractspec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+)
+
+// SpecSectionName is the WASM custom section holding a contract's spec.
+const SpecSectionName = "contractspecv0"
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+
+// ErrNoSpec is returned for WASM without a contractspecv0 section.
+var ErrNoSpec = errors.New("wasm has no " + SpecSectionName + " section")
+
+// Spec is the set of entries a contract declares.
+type Spec struct {
+	Entries []xdr.ScSpecEntry
+}
+
+// ParseWasm reads the spec embedded in a contract's WASM.
+func ParseWasm(wasm []byte) (*Spec, error) {
+	sections, err := WasmCustomSections(wasm, SpecSectionName)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) == 0 {
+		return nil, ErrNoSpec
+	}
+	return ParseSpec(bytes.Join(sections, nil))
+}
+
+// ParseSpec decodes a contractspecv0 section: ScSpecEntry values back to
+// back, without a length prefix.
+func ParseSpec(data []byte) (*Spec, error) {
+	spec := &Spec{}
+	reader := bytes.NewReader(data)
+	for reader.Len() > 0 {
+		offset := len(data) - reader.Len()
+		var entry xdr.ScSpecEntry
+		if _, err := xdr.Unmarshal(reader, &entry); err != nil {
+			return nil, fmt.Errorf("error decoding spec entry at offset %d: %w", offset, err)
+		}
+		spec.Entries = append(spec.Entries, entry)
+	}
+	return spec, nil
+}
+
+// MarshalBinary encodes the spec the way it is stored in the contractspecv0
+// section.
+func (s *Spec) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	for idx, entry := range s.Entries {
+		if _, err := xdr.Marshal(&buf, entry); err != nil {
+			return nil, fmt.Errorf("error encoding spec entry %d: %w", idx, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Functions returns the contract's functions in declaration order.
+func (s *Spec) Functions() []xdr.ScSpecFunctionV0 {
+	var functions []xdr.ScSpecFunctionV0
+	for _, entry := range s.Entries {
+		if function, ok := entry.GetFunctionV0(); ok {
+			functions = append(functions, function)
+		}
+	}
+	return functions
+}
+
+// Function returns the function called name.
+func (s *Spec) Function(name string) (xdr.ScSpecFunctionV0, bool) {
+	for _, function := range s.Functions() {
+		if string(function.Name) == name {
+			return function, true
+		}
+	}
+	return xdr.ScSpecFunctionV0{}, false
+}
+
+// Type returns the struct, union, enum or error enum called name.
+func (s *Spec) Type(name string) (xdr.ScSpecEntry, bool) {
+	for _, entry := range s.Entries {
+		if udtName(entry) == name {
+			return entry, true
+		}
+	}
+	return xdr.ScSpecEntry{}, false
+}
+
+// udtName is the name of a user defined type entry, or "" for functions and
+// events.
+func udtName(entry xdr.ScSpecEntry) string {
+	switch entry.Kind {
+	case xdr.ScSpecEntryKindScSpecEntryUdtStructV0:
+		return entry.UdtStructV0.Name
+	case xdr.ScSpecEntryKindScSpecEntryUdtUnionV0:
+		return entry.UdtUnionV0.Name
+	case xdr.ScSpecEntryKindScSpecEntryUdtEnumV0:
+		return entry.UdtEnumV0.Name
+	case xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0:
+		return entry.UdtErrorEnumV0.Name
+	default:
+		return ""
+	}
+}
+
+// WasmCustomSections returns the payloads of the custom sections called name,
+// in the order they appear.
+func WasmCustomSections(wasm []byte, name string) ([][]byte, error) {
+	if len(wasm) < 8 || !bytes.Equal(wasm[:4], wasmMagic) {
+		return nil, errors.New("not a wasm module")
+	}
+
+	var sections [][]byte
+	pos := 8
+	for pos < len(wasm) {
+		id := wasm[pos]
+		pos++
+
+		size, n := binary.Uvarint(wasm[pos:])
+		if n <= 0 || size > uint64(len(wasm)-pos-n) {
+			return nil, fmt.Errorf("invalid size of section %d at offset %d", id, pos)
+		}
+		pos += n
+		payload := wasm[pos : pos+int(size)]
+		pos += int(size)
+
+		if id != 0 {
+			continue
+		}
+		nameLen, n := binary.Uvarint(payload)
+		if n <= 0 || nameLen > uint64(len(payload)-n) {
+			return nil, fmt.Errorf("invalid custom section name at offset %d", pos-int(size))
+		}
+		if string(payload[n:n+int(nameLen)]) == name {
+			sections = append(sections, payload[n+int(nameLen):])
+		}
+	}
+	return sections, nil
+}
@@ -0,0 +1,230 @@
+package contractspec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the generated test bindings")
+
+const testBindingsPath = "internal/testbindings/bindings.go"
+
+func specType(t xdr.ScSpecType) xdr.ScSpecTypeDef {
+	return xdr.ScSpecTypeDef{Type: t}
+}
+
+func udtType(name string) xdr.ScSpecTypeDef {
+	return xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeUdt, Udt: &xdr.ScSpecTypeUdt{Name: name}}
+}
+
+func vecType(elem xdr.ScSpecTypeDef) xdr.ScSpecTypeDef {
+	return xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeVec, Vec: &xdr.ScSpecTypeVec{ElementType: elem}}
+}
+
+// testSpec is a small contract in the style of Blend's backstop, covering
+// every kind of entry.
+func testSpec() *Spec {
+	address := specType(xdr.ScSpecTypeScSpecTypeAddress)
+	i128 := specType(xdr.ScSpecTypeScSpecTypeI128)
+	u128 := specType(xdr.ScSpecTypeScSpecTypeU128)
+
+	entries := []xdr.ScSpecEntry{
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtStructV0, UdtStructV0: &xdr.ScSpecUdtStructV0{
+			Doc:  "A queued withdrawal.",
+			Name: "Q4W",
+			Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "amount", Type: i128},
+				{Name: "exp", Type: specType(xdr.ScSpecTypeScSpecTypeU64)},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtStructV0, UdtStructV0: &xdr.ScSpecUdtStructV0{
+			Name: "UserBalance",
+			Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "q4w", Type: vecType(udtType("Q4W"))},
+				{Name: "shares", Type: i128},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtStructV0, UdtStructV0: &xdr.ScSpecUdtStructV0{
+			Name: "PoolUserKey",
+			Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "pool", Type: address},
+				{Name: "user", Type: address},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtStructV0, UdtStructV0: &xdr.ScSpecUdtStructV0{
+			Name: "Pair",
+			Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "0", Type: address},
+				{Name: "1", Type: u128},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtUnionV0, UdtUnionV0: &xdr.ScSpecUdtUnionV0{
+			Name: "DataKey",
+			Cases: []xdr.ScSpecUdtUnionCaseV0{
+				{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0, TupleCase: &xdr.ScSpecUdtUnionCaseTupleV0{
+					Name: "UserBalance", Type: []xdr.ScSpecTypeDef{udtType("PoolUserKey")},
+				}},
+				{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0, TupleCase: &xdr.ScSpecUdtUnionCaseTupleV0{
+					Name: "PoolBalance", Type: []xdr.ScSpecTypeDef{address},
+				}},
+				{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseVoidV0, VoidCase: &xdr.ScSpecUdtUnionCaseVoidV0{
+					Doc: "The reward zone.", Name: "RZ",
+				}},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtEnumV0, UdtEnumV0: &xdr.ScSpecUdtEnumV0{
+			Name: "ReserveStatus",
+			Cases: []xdr.ScSpecUdtEnumCaseV0{
+				{Name: "Active", Value: 0},
+				{Name: "Frozen", Value: 1},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryUdtErrorEnumV0, UdtErrorEnumV0: &xdr.ScSpecUdtErrorEnumV0{
+			Name: "BackstopError",
+			Cases: []xdr.ScSpecUdtErrorEnumCaseV0{
+				{Name: "BadRequest", Value: 1000},
+				{Name: "InsufficientFunds", Value: 1001},
+			},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Doc:  "Deposits backstop tokens into a pool's backstop.",
+			Name: "deposit",
+			Inputs: []xdr.ScSpecFunctionInputV0{
+				{Name: "from", Type: address},
+				{Name: "pool_address", Type: address},
+				{Name: "amount", Type: i128},
+			},
+			Outputs: []xdr.ScSpecTypeDef{i128},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:    "user_balance",
+			Inputs:  []xdr.ScSpecFunctionInputV0{{Name: "pool", Type: address}, {Name: "user", Type: address}},
+			Outputs: []xdr.ScSpecTypeDef{udtType("UserBalance")},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:    "reward_zone",
+			Outputs: []xdr.ScSpecTypeDef{vecType(address)},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:   "set_reward_zone",
+			Inputs: []xdr.ScSpecFunctionInputV0{{Name: "pools", Type: vecType(address)}},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name: "set_status",
+			Inputs: []xdr.ScSpecFunctionInputV0{
+				{Name: "status", Type: udtType("ReserveStatus")},
+				{Name: "supply", Type: u128},
+				{Name: "limits", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeMap, Map: &xdr.ScSpecTypeMap{
+					KeyType: specType(xdr.ScSpecTypeScSpecTypeSymbol), ValueType: i128,
+				}}},
+				{Name: "note", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeOption, Option: &xdr.ScSpecTypeOption{
+					ValueType: specType(xdr.ScSpecTypeScSpecTypeString),
+				}}},
+			},
+			Outputs: []xdr.ScSpecTypeDef{{Type: xdr.ScSpecTypeScSpecTypeResult, Result: &xdr.ScSpecTypeResult{
+				OkType: specType(xdr.ScSpecTypeScSpecTypeVoid), ErrorType: udtType("BackstopError"),
+			}}},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:    "key",
+			Inputs:  []xdr.ScSpecFunctionInputV0{{Name: "type", Type: udtType("DataKey")}},
+			Outputs: []xdr.ScSpecTypeDef{{Type: xdr.ScSpecTypeScSpecTypeOption, Option: &xdr.ScSpecTypeOption{ValueType: udtType("Pair")}}},
+		}},
+		{Kind: xdr.ScSpecEntryKindScSpecEntryEventV0, EventV0: &xdr.ScSpecEventV0{
+			Name:         "deposit",
+			PrefixTopics: []xdr.ScSymbol{"deposit"},
+		}},
+	}
+	return &Spec{Entries: entries}
+}
+
+// testWasm wraps a spec in a minimal module: a type section followed by the
+// contractspecv0 section split in two, as linkers may emit it.
+func testWasm(t *testing.T, spec *Spec) []byte {
+	data, err := spec.MarshalBinary()
+	require.NoError(t, err)
+
+	section := func(id byte, payload []byte) []byte {
+		out := []byte{id}
+		out = binary.AppendUvarint(out, uint64(len(payload)))
+		return append(out, payload...)
+	}
+	custom := func(name string, payload []byte) []byte {
+		body := binary.AppendUvarint(nil, uint64(len(name)))
+		body = append(body, name...)
+		return section(0, append(body, payload...))
+	}
+
+	wasm := append([]byte{}, wasmMagic...)
+	wasm = append(wasm, 1, 0, 0, 0)
+	wasm = append(wasm, section(1, []byte{0})...)
+	wasm = append(wasm, custom("contractmetav0", []byte{1, 2, 3})...)
+	wasm = append(wasm, custom(SpecSectionName, data[:len(data)/2])...)
+	wasm = append(wasm, custom(SpecSectionName, data[len(data)/2:])...)
+	return wasm
+}
+
+func TestParseWasm(t *testing.T) {
+	spec := testSpec()
+	parsed, err := ParseWasm(testWasm(t, spec))
+	require.NoError(t, err)
+	require.Len(t, parsed.Entries, len(spec.Entries))
+
+	expected, err := spec.MarshalBinary()
+	require.NoError(t, err)
+	actual, err := parsed.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	assert.Len(t, parsed.Functions(), 6)
+	deposit, ok := parsed.Function("deposit")
+	require.True(t, ok)
+	assert.Len(t, deposit.Inputs, 3)
+	_, ok = parsed.Type("DataKey")
+	assert.True(t, ok)
+
+	_, err = ParseWasm(append([]byte{}, wasmMagic[0], wasmMagic[1], wasmMagic[2], wasmMagic[3], 1, 0, 0, 0))
+	assert.ErrorIs(t, err, ErrNoSpec)
+	_, err = ParseWasm([]byte("not wasm"))
+	assert.Error(t, err)
+	_, err = ParseSpec([]byte{0, 0, 0})
+	assert.Error(t, err)
+}
+
+func TestGenerateMatchesTestBindings(t *testing.T) {
+	code, err := Generate(testSpec(), GenerateOptions{Package: "testbindings", Source: "spec_test.go"})
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(testBindingsPath, code, 0o644))
+	}
+	existing, err := os.ReadFile(testBindingsPath)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(existing, code), "%s is stale; run go test ./pkg/contractspec -update", testBindingsPath)
+}
+
+func TestGenerateRejectsUnknownTypes(t *testing.T) {
+	spec := &Spec{Entries: []xdr.ScSpecEntry{
+		{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:   "f",
+			Inputs: []xdr.ScSpecFunctionInputV0{{Name: "x", Type: udtType("Missing")}},
+		}},
+	}}
+	_, err := Generate(spec, GenerateOptions{Package: "p"})
+	assert.ErrorContains(t, err, "unknown type Missing")
+}
+
+func TestExportedName(t *testing.T) {
+	assert.Equal(t, "UserBalance", exportedName("user_balance"))
+	assert.Equal(t, "UserBalance", exportedName("UserBalance"))
+	assert.Equal(t, "Q4w", exportedName("q4w"))
+	assert.Equal(t, "V0", exportedName("0"))
+	assert.Equal(t, "poolAddress", unexportedName("pool_address"))
+}
@@ -0,0 +1,227 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
+)
+
+const DefaultResourceFeeMargin = 0.15
+
+// SubmitOptions controls how a simulated transaction is assembled and
+// submitted.
+type SubmitOptions struct {
+	// BaseFee is the inclusion fee paid on top of the resource fee.
+	BaseFee int64
+	// ResourceFeeMargin is added to the simulated minimum resource fee as a
+	// fraction of it, e.g. 0.15 bids 15% more than the simulation asked for.
+	ResourceFeeMargin float64
+	// PollInterval is the first delay between getTransaction polls while
+	// waiting for a submitted transaction. It grows by half on every poll up
+	// to MaxPollInterval.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	// AutoRestore restores archived ledger entries when simulation returns a
+	// RestorePreamble, then simulates the original call again, and lets
+	// ExtendFootprintTTL restore archived entries before extending them.
+	// Restoring requires the source account to sign and pay for a
+	// RestoreFootprint transaction.
+	AutoRestore bool
+}
+
+func DefaultSubmitOptions() SubmitOptions {
+	return SubmitOptions{
+		BaseFee:           txnbuild.MinBaseFee,
+		ResourceFeeMargin: DefaultResourceFeeMargin,
+		PollInterval:      DefaultPollInterval,
+		MaxPollInterval:   DefaultMaxPollInterval,
+	}
+}
+
+// RestoreRequiredError is returned when simulation reports that archived
+// ledger entries in the footprint must be restored before the call can run.
+type RestoreRequiredError struct {
+	Preamble protocol.RestorePreamble
+}
+
+func (e *RestoreRequiredError) Error() string {
+	return fmt.Sprintf(
+		"archived ledger entries must be restored before submitting (restore min resource fee: %d)",
+		e.Preamble.MinResourceFee,
+	)
+}
+
+// PrepareContractCall builds a contract invocation, simulates it and returns
+// the transaction with the simulated footprint, resources, auth and fees
+// applied, ready to be signed. The source account's sequence number is only
+// incremented once simulation succeeds, so a RestoreRequiredError leaves it
+// untouched for the restore transaction.
+func PrepareContractCall(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+	opts SubmitOptions,
+) (*txnbuild.Transaction, error) {
+	transactionXdr, err := buildContractTx(contractAddress, sourceAccount, args, functionName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation, err := simulateTransaction(ctx, rpc, transactionXdr)
+	if err != nil {
+		return nil, err
+	}
+
+	if simulation.RestorePreamble != nil {
+		return nil, &RestoreRequiredError{Preamble: *simulation.RestorePreamble}
+	}
+
+	transactionXdr, err = buildContractTx(contractAddress, sourceAccount, args, functionName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return AssembleTransaction(transactionXdr, simulation, opts)
+}
+
+// AssembleTransaction applies a simulation response to the single Soroban
+// operation of tx. The resource fee is the simulated minimum plus the
+// configured margin, and the inclusion fee is opts.BaseFee.
+func AssembleTransaction(
+	tx *txnbuild.Transaction,
+	simulation protocol.SimulateTransactionResponse,
+	opts SubmitOptions,
+) (*txnbuild.Transaction, error) {
+	if simulation.Error != "" {
+		return nil, newSimulationError(simulation)
+	}
+
+	if simulation.RestorePreamble != nil {
+		return nil, &RestoreRequiredError{Preamble: *simulation.RestorePreamble}
+	}
+
+	var transactionData xdr.SorobanTransactionData
+	err := xdrjson.Decode(simulation.TransactionDataXDR, simulation.TransactionDataJSON, &transactionData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding simulated transaction data: %w", err)
+	}
+
+	transactionData.ResourceFee = xdr.Int64(withMargin(simulation.MinResourceFee, opts.ResourceFeeMargin))
+	ext := xdr.TransactionExt{V: 1, SorobanData: &transactionData}
+
+	operations := tx.Operations()
+	if len(operations) != 1 {
+		return nil, fmt.Errorf("soroban transactions must have exactly one operation, got %d", len(operations))
+	}
+
+	var op txnbuild.Operation
+	switch source := operations[0].(type) {
+	case *txnbuild.InvokeHostFunction:
+		invoke := *source
+		invoke.Ext = ext
+		if len(invoke.Auth) == 0 {
+			invoke.Auth, err = decodeSimulatedAuth(simulation)
+			if err != nil {
+				return nil, err
+			}
+		}
+		op = &invoke
+	case *txnbuild.ExtendFootprintTtl:
+		extend := *source
+		extend.Ext = ext
+		op = &extend
+	case *txnbuild.RestoreFootprint:
+		restore := *source
+		restore.Ext = ext
+		op = &restore
+	default:
+		return nil, fmt.Errorf("cannot assemble %T operation", source)
+	}
+
+	baseFee := opts.BaseFee
+	if baseFee < txnbuild.MinBaseFee {
+		baseFee = txnbuild.MinBaseFee
+	}
+
+	sourceAccount := tx.SourceAccount()
+	return txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &sourceAccount,
+		IncrementSequenceNum: false,
+		BaseFee:              baseFee,
+		Memo:                 tx.Memo(),
+		Preconditions: txnbuild.Preconditions{
+			TimeBounds: tx.Timebounds(),
+		},
+		Operations: []txnbuild.Operation{op},
+	})
+}
+
+func decodeSimulatedAuth(simulation protocol.SimulateTransactionResponse) ([]xdr.SorobanAuthorizationEntry, error) {
+	if len(simulation.Results) == 0 {
+		return nil, nil
+	}
+
+	result := simulation.Results[0]
+	if result.AuthXDR == nil {
+		auth := make([]xdr.SorobanAuthorizationEntry, len(result.AuthJSON))
+		for idx, entry := range result.AuthJSON {
+			if err := xdrjson.Unmarshal(entry, &auth[idx]); err != nil {
+				return nil, fmt.Errorf("error decoding auth entry at index %d: %w", idx, err)
+			}
+		}
+		return auth, nil
+	}
+
+	auth := make([]xdr.SorobanAuthorizationEntry, len(*result.AuthXDR))
+	for idx, entry := range *result.AuthXDR {
+		err := xdr.SafeUnmarshalBase64(entry, &auth[idx])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding auth entry at index %d: %w", idx, err)
+		}
+	}
+	return auth, nil
+}
+
+func withMargin(fee int64, margin float64) int64 {
+	if margin <= 0 {
+		return fee
+	}
+	return fee + int64(math.Ceil(float64(fee)*margin))
+}
+
+func simulateTransaction(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	tx *txnbuild.Transaction,
+) (protocol.SimulateTransactionResponse, error) {
+	transactionBase64, err := tx.Base64()
+	if err != nil {
+		return protocol.SimulateTransactionResponse{}, err
+	}
+
+	response, err := rpc.SimulateTransaction(
+		ctx,
+		protocol.SimulateTransactionRequest{
+			Transaction: transactionBase64,
+		},
+	)
+	if err != nil {
+		return protocol.SimulateTransactionResponse{}, err
+	}
+
+	if response.Error != "" {
+		return protocol.SimulateTransactionResponse{}, newSimulationError(response)
+	}
+
+	return response, nil
+}
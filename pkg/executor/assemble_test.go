@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestAssembleTransaction(t *testing.T) {
+	source := txnbuild.NewSimpleAccount(keypair.MustRandom().Address(), 10)
+	contractId := xdr.ContractId{1}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractId}
+
+	tx, err := buildContractTx(contract, &source, nil, "balance", true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), tx.SequenceNumber())
+
+	transactionData := xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{Instructions: 1000},
+	}
+	transactionDataXdr, err := xdr.MarshalBase64(transactionData)
+	require.NoError(t, err)
+
+	assembled, err := AssembleTransaction(tx, protocol.SimulateTransactionResponse{
+		TransactionDataXDR: transactionDataXdr,
+		MinResourceFee:     1000,
+	}, SubmitOptions{BaseFee: 200, ResourceFeeMargin: 0.1})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(11), assembled.SequenceNumber())
+	assert.Equal(t, int64(200+1100), assembled.MaxFee())
+
+	sorobanData := assembled.ToXDR().V1.Tx.Ext.SorobanData
+	require.NotNil(t, sorobanData)
+	assert.Equal(t, xdr.Int64(1100), sorobanData.ResourceFee)
+	assert.Equal(t, xdr.Uint32(1000), sorobanData.Resources.Instructions)
+
+	_, err = AssembleTransaction(tx, protocol.SimulateTransactionResponse{
+		TransactionDataXDR: transactionDataXdr,
+		RestorePreamble:    &protocol.RestorePreamble{MinResourceFee: 50},
+	}, DefaultSubmitOptions())
+	var restoreErr *RestoreRequiredError
+	require.True(t, errors.As(err, &restoreErr))
+	assert.Equal(t, int64(50), restoreErr.Preamble.MinResourceFee)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stellar/go/xdr"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
 )
 
 func SimulateContractCall(
@@ -18,36 +19,48 @@ func SimulateContractCall(
 	args []xdr.ScVal,
 	functionName xdr.ScSymbol,
 ) (*xdr.ScVal, error) {
+	return SimulateContractCallContext(context.Background(), rpc, contractAddress, sourceAccount, args, functionName)
+}
 
-	transactionXdr, err := buildContractTx(contractAddress, sourceAccount, args, functionName)
-	if err != nil {
-		return nil, err
-	}
+func SimulateContractCallContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+) (*xdr.ScVal, error) {
 
-	transactionBase64, err := transactionXdr.Base64()
+	transactionXdr, err := buildContractTx(contractAddress, sourceAccount, args, functionName, false)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := rpc.SimulateTransaction(
-		context.TODO(),
-		protocol.SimulateTransactionRequest{
-			Transaction: transactionBase64,
-		},
-	)
-
+	response, err := simulateTransaction(ctx, rpc, transactionXdr)
 	if err != nil {
 		return nil, err
 	}
 
-	var responseScVal xdr.ScVal
-
 	if len(response.Results) != 1 {
 		return nil, fmt.Errorf("unexpected number of simulation results: %d", len(response.Results))
 	}
 
+	result := response.Results[0]
+	if result.ReturnValueXDR == nil {
+		if len(result.ReturnValueJSON) == 0 {
+			return nil, fmt.Errorf("simulation result has no return value")
+		}
+		responseScVal, err := xdrjson.UnmarshalScVal(result.ReturnValueJSON)
+		if err != nil {
+			return nil, err
+		}
+		return &responseScVal, nil
+	}
+
+	var responseScVal xdr.ScVal
+
 	err = xdr.SafeUnmarshalBase64(
-		*response.Results[0].ReturnValueXDR,
+		*result.ReturnValueXDR,
 		&responseScVal,
 	)
 
@@ -58,7 +71,6 @@ func SimulateContractCall(
 	return &responseScVal, err
 }
 
-// Untested
 func SubmitContractCall(
 	rpc *soroban.RpcClient,
 	contractAddress xdr.ScAddress,
@@ -68,44 +80,103 @@ func SubmitContractCall(
 	networkPassphrase string,
 	signingKeypairs []*keypair.Full,
 ) (string, error) {
-	transactionXdr, err := buildContractTx(contractAddress, sourceAccount, args, functionName)
+	return SubmitContractCallContext(
+		context.Background(),
+		rpc,
+		contractAddress,
+		sourceAccount,
+		args,
+		functionName,
+		networkPassphrase,
+		signingKeypairs,
+	)
+}
+
+func SubmitContractCallContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+) (string, error) {
+	return SubmitContractCallWithOptions(
+		ctx,
+		rpc,
+		contractAddress,
+		sourceAccount,
+		args,
+		functionName,
+		networkPassphrase,
+		signingKeypairs,
+		DefaultSubmitOptions(),
+	)
+}
+
+// SubmitContractCallWithOptions simulates the call, assembles the simulated
+// footprint, resources, auth and fees into the transaction, then signs and
+// submits it. It returns the transaction hash once the server has accepted
+// the transaction, resubmitting it with backoff while the server answers
+// TRY_AGAIN_LATER. With opts.AutoRestore set, archived entries in the
+// footprint are restored first.
+func SubmitContractCallWithOptions(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (string, error) {
+	transactionXdr, err := prepareContractCallWithRestore(
+		ctx,
+		rpc,
+		contractAddress,
+		sourceAccount,
+		args,
+		functionName,
+		networkPassphrase,
+		signingKeypairs,
+		opts,
+	)
 	if err != nil {
 		return "", err
 	}
 
+	return submitTransaction(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs, opts)
+}
+
+func signAndSend(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	transactionXdr *txnbuild.Transaction,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+) (protocol.SendTransactionResponse, error) {
+	var err error
 	for _, keypair := range signingKeypairs {
 		transactionXdr, err = transactionXdr.Sign(
 			networkPassphrase,
 			keypair,
 		)
 		if err != nil {
-			return "", err
+			return protocol.SendTransactionResponse{}, err
 		}
 	}
 
 	transactionBase64, err := transactionXdr.Base64()
 	if err != nil {
-		return "", err
+		return protocol.SendTransactionResponse{}, err
 	}
 
-	response, err := rpc.SendTransaction(
-		context.TODO(),
+	return rpc.SendTransaction(
+		ctx,
 		protocol.SendTransactionRequest{
 			Transaction: transactionBase64,
 		},
 	)
-	if err != nil {
-		return "", err
-	}
-
-	if response.ErrorResultXDR != "" {
-		var xdrErr xdr.ScError
-
-		err := xdr.SafeUnmarshalBase64(response.ErrorResultXDR, &xdrErr)
-		if err != nil {
-			return "", err
-		}
-		return "", fmt.Errorf("contract error: %+v", xdrErr)
-	}
-	return response.Hash, nil
 }
@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func newSubmitServer(t *testing.T, sends ...string) (*mockRPC, func() (string, error)) {
+	contractId := xdr.ContractId{1}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractId}
+	callDataXdr, err := xdr.MarshalBase64(xdr.SorobanTransactionData{})
+	require.NoError(t, err)
+
+	server, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.SimulateTransactionMethodName: staticResult(fmt.Sprintf(
+			`{"transactionData":%q,"minResourceFee":"10","results":[{"xdr":"AAAAAQ=="}],"latestLedger":5}`,
+			callDataXdr,
+		)),
+		protocol.SendTransactionMethodName: sequentialResults(sends...),
+	})
+
+	signer := keypair.MustRandom()
+	source := txnbuild.NewSimpleAccount(signer.Address(), 10)
+	opts := SubmitOptions{BaseFee: 100, PollInterval: time.Millisecond}
+	return server, func() (string, error) {
+		return SubmitContractCallWithOptions(
+			context.Background(), rpc, contract, &source, nil, "bump",
+			network.TestNetworkPassphrase, []*keypair.Full{signer}, opts,
+		)
+	}
+}
+
+func TestSubmitContractCallResubmitsTryAgainLater(t *testing.T) {
+	server, submit := newSubmitServer(t,
+		`{"status":"TRY_AGAIN_LATER","hash":"abcd","latestLedgerCloseTime":"100"}`,
+		`{"status":"PENDING","hash":"abcd"}`,
+	)
+
+	hash, err := submit()
+	require.NoError(t, err)
+	assert.Equal(t, "abcd", hash)
+	assert.Equal(t, []string{
+		protocol.SimulateTransactionMethodName,
+		protocol.SendTransactionMethodName,
+		protocol.SendTransactionMethodName,
+	}, server.calls())
+}
+
+func TestSubmitContractCallRejectsUnacceptedStatuses(t *testing.T) {
+	_, submit := newSubmitServer(t, `{"status":"ERROR","hash":"abcd"}`)
+	_, err := submit()
+	var txErr *TransactionError
+	assert.True(t, errors.As(err, &txErr))
+
+	_, submit = newSubmitServer(t, `{"status":"UNKNOWN","hash":"abcd"}`)
+	_, err = submit()
+	assert.ErrorContains(t, err, `unexpected send transaction status "UNKNOWN"`)
+}
@@ -0,0 +1,294 @@
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
+)
+
+// The host renders contract errors as e.g. "HostError: Error(Contract, #1205)"
+var contractErrorPattern = regexp.MustCompile(`Error\(Contract, #(\d+)\)`)
+
+// ContractError is an error code returned by a contract through its
+// contracterror enum, e.g. a Blend pool's #1205.
+type ContractError struct {
+	// ContractID is the strkey of the contract that raised the error, when it
+	// is known from the diagnostic events.
+	ContractID string
+	Code       uint32
+}
+
+func (e *ContractError) Error() string {
+	if e.ContractID == "" {
+		return fmt.Sprintf("contract error #%d", e.Code)
+	}
+	return fmt.Sprintf("contract error #%d in %s", e.Code, e.ContractID)
+}
+
+// HostError is an error raised by the Soroban host itself rather than by a
+// contract, e.g. an exceeded budget or a failed auth check.
+type HostError struct {
+	ContractID string
+	ScError    xdr.ScError
+}
+
+func (e *HostError) Error() string {
+	msg := fmt.Sprintf("host error %s", e.ScError.Type)
+	if e.ScError.Code != nil {
+		msg = fmt.Sprintf("%s (%s)", msg, *e.ScError.Code)
+	}
+	if e.ContractID != "" {
+		msg = fmt.Sprintf("%s in %s", msg, e.ContractID)
+	}
+	return msg
+}
+
+// IsContractError reports whether err carries a ContractError with the given code.
+func IsContractError(err error, code uint32) bool {
+	var contractErr *ContractError
+	return errors.As(err, &contractErr) && contractErr.Code == code
+}
+
+// TransactionError is returned when a transaction was rejected on submission
+// or failed when applied. The contract or host error behind it, if any, can
+// be reached with errors.As.
+type TransactionError struct {
+	Hash string
+	// Rejected is set when stellar-core refused the transaction on submission
+	// instead of applying it and recording a failure.
+	Rejected bool
+	Result   xdr.TransactionResult
+	// Code is the transaction result code, taken from the inner transaction
+	// for fee bumps.
+	Code xdr.TransactionResultCode
+	// InvokeHostFunctionCode is set when the transaction's InvokeHostFunction
+	// operation produced a result.
+	InvokeHostFunctionCode *xdr.InvokeHostFunctionResultCode
+	DiagnosticEvents       []xdr.DiagnosticEvent
+	// Cause is the contract or host error decoded from the diagnostic events.
+	Cause error
+}
+
+func (e *TransactionError) Error() string {
+	var b strings.Builder
+	b.WriteString("transaction ")
+	if e.Hash != "" {
+		b.WriteString(e.Hash + " ")
+	}
+	if e.Rejected {
+		b.WriteString("rejected: ")
+	} else {
+		b.WriteString("failed: ")
+	}
+	b.WriteString(e.Code.String())
+	if e.InvokeHostFunctionCode != nil {
+		fmt.Fprintf(&b, " (%s)", *e.InvokeHostFunctionCode)
+	}
+	if e.Cause != nil {
+		fmt.Fprintf(&b, ": %s", e.Cause)
+	}
+	return b.String()
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Cause
+}
+
+// SimulationError is returned when simulateTransaction reports an error.
+type SimulationError struct {
+	Message          string
+	DiagnosticEvents []xdr.DiagnosticEvent
+	// Cause is the contract or host error decoded from the diagnostic events
+	// or, failing that, from the message.
+	Cause error
+}
+
+func (e *SimulationError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("simulation failed: %s: %s", e.Cause, e.Message)
+	}
+	return fmt.Sprintf("simulation failed: %s", e.Message)
+}
+
+func (e *SimulationError) Unwrap() error {
+	return e.Cause
+}
+
+func newSimulationError(response protocol.SimulateTransactionResponse) error {
+	events, err := decodeDiagnosticEvents(response.EventsXDR, response.EventsJSON)
+	if err != nil {
+		return err
+	}
+
+	simErr := &SimulationError{
+		Message:          response.Error,
+		DiagnosticEvents: events,
+		Cause:            errorFromDiagnosticEvents(events),
+	}
+	if simErr.Cause == nil {
+		simErr.Cause = contractErrorFromMessage(response.Error)
+	}
+	return simErr
+}
+
+func newSendTransactionError(response protocol.SendTransactionResponse) error {
+	return newTransactionError(
+		response.Hash, true,
+		response.ErrorResultXDR, response.ErrorResultJSON,
+		response.DiagnosticEventsXDR, response.DiagnosticEventsJSON,
+		nil,
+	)
+}
+
+func newTransactionFailedError(
+	hash string,
+	response protocol.GetTransactionResponse,
+	meta *xdr.TransactionMeta,
+) error {
+	return newTransactionError(
+		hash, false,
+		response.ResultXDR, response.ResultJSON,
+		response.DiagnosticEventsXDR, response.DiagnosticEventsJSON,
+		meta,
+	)
+}
+
+func newTransactionError(
+	hash string,
+	rejected bool,
+	resultXdr string,
+	resultJSON json.RawMessage,
+	diagnosticEventsXdr []string,
+	diagnosticEventsJSON []json.RawMessage,
+	meta *xdr.TransactionMeta,
+) error {
+	txErr := &TransactionError{Hash: hash, Rejected: rejected}
+
+	if resultXdr != "" || len(resultJSON) > 0 {
+		err := xdrjson.Decode(resultXdr, resultJSON, &txErr.Result)
+		if err != nil {
+			return fmt.Errorf("error decoding transaction result for %s: %w", hash, err)
+		}
+		txErr.Code, txErr.InvokeHostFunctionCode = transactionResultCodes(txErr.Result)
+	}
+
+	events, err := decodeDiagnosticEvents(diagnosticEventsXdr, diagnosticEventsJSON)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 && meta != nil {
+		events, err = meta.GetDiagnosticEvents()
+		if err != nil {
+			return err
+		}
+	}
+	txErr.DiagnosticEvents = events
+	txErr.Cause = errorFromDiagnosticEvents(events)
+
+	return txErr
+}
+
+func transactionResultCodes(result xdr.TransactionResult) (
+	xdr.TransactionResultCode,
+	*xdr.InvokeHostFunctionResultCode,
+) {
+	code := result.Result.Code
+	operations := result.Result.Results
+
+	if pair, ok := result.Result.GetInnerResultPair(); ok {
+		code = pair.Result.Result.Code
+		operations = pair.Result.Result.Results
+	}
+
+	if operations == nil {
+		return code, nil
+	}
+	for _, op := range *operations {
+		if op.Tr == nil {
+			continue
+		}
+		if invokeResult, ok := op.Tr.GetInvokeHostFunctionResult(); ok {
+			invokeCode := invokeResult.Code
+			return code, &invokeCode
+		}
+	}
+	return code, nil
+}
+
+// decodeDiagnosticEvents decodes the events of a response fetched in either
+// format.
+func decodeDiagnosticEvents(eventsXdr []string, eventsJSON []json.RawMessage) ([]xdr.DiagnosticEvent, error) {
+	if len(eventsXdr) == 0 {
+		events := make([]xdr.DiagnosticEvent, len(eventsJSON))
+		for idx, eventJSON := range eventsJSON {
+			event, err := xdrjson.UnmarshalDiagnosticEvent(eventJSON)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding diagnostic event at index %d: %w", idx, err)
+			}
+			events[idx] = event
+		}
+		return events, nil
+	}
+
+	events := make([]xdr.DiagnosticEvent, len(eventsXdr))
+	for idx, eventXdr := range eventsXdr {
+		err := xdr.SafeUnmarshalBase64(eventXdr, &events[idx])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding diagnostic event at index %d: %w", idx, err)
+		}
+	}
+	return events, nil
+}
+
+// errorFromDiagnosticEvents returns the first error the host logged. The host
+// emits an ["error", ScError] diagnostic event from the frame that failed
+// before any frames above it, so the first one is the root cause.
+func errorFromDiagnosticEvents(events []xdr.DiagnosticEvent) error {
+	for _, event := range events {
+		body, ok := event.Event.Body.GetV0()
+		if !ok || len(body.Topics) < 2 {
+			continue
+		}
+
+		sym, ok := body.Topics[0].GetSym()
+		if !ok || sym != "error" {
+			continue
+		}
+
+		scErr, ok := body.Topics[1].GetError()
+		if !ok {
+			continue
+		}
+
+		var contractID string
+		if event.Event.ContractId != nil {
+			contractID = strkey.MustEncode(strkey.VersionByteContract, event.Event.ContractId[:])
+		}
+
+		if contractCode, ok := scErr.GetContractCode(); ok {
+			return &ContractError{ContractID: contractID, Code: uint32(contractCode)}
+		}
+		return &HostError{ContractID: contractID, ScError: scErr}
+	}
+	return nil
+}
+
+func contractErrorFromMessage(message string) error {
+	match := contractErrorPattern.FindStringSubmatch(message)
+	if match == nil {
+		return nil
+	}
+	code, err := strconv.ParseUint(match[1], 10, 32)
+	if err != nil {
+		return nil
+	}
+	return &ContractError{Code: uint32(code)}
+}
@@ -0,0 +1,86 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestSendTransactionErrorDecodesContractError(t *testing.T) {
+	contractId := xdr.ContractId{7}
+	errorSym := xdr.ScSymbol("error")
+	contractCode := xdr.Uint32(1205)
+	event := xdr.DiagnosticEvent{
+		Event: xdr.ContractEvent{
+			ContractId: &contractId,
+			Type:       xdr.ContractEventTypeDiagnostic,
+			Body: xdr.ContractEventBody{
+				V: 0,
+				V0: &xdr.ContractEventV0{
+					Topics: []xdr.ScVal{
+						{Type: xdr.ScValTypeScvSymbol, Sym: &errorSym},
+						{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{
+							Type:         xdr.ScErrorTypeSceContract,
+							ContractCode: &contractCode,
+						}},
+					},
+					Data: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+				},
+			},
+		},
+	}
+	eventXdr, err := xdr.MarshalBase64(event)
+	require.NoError(t, err)
+
+	invokeResult := xdr.InvokeHostFunctionResult{Code: xdr.InvokeHostFunctionResultCodeInvokeHostFunctionTrapped}
+	operations := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type:                     xdr.OperationTypeInvokeHostFunction,
+			InvokeHostFunctionResult: &invokeResult,
+		},
+	}}
+	resultXdr, err := xdr.MarshalBase64(xdr.TransactionResult{
+		Result: xdr.TransactionResultResult{
+			Code:    xdr.TransactionResultCodeTxFailed,
+			Results: &operations,
+		},
+	})
+	require.NoError(t, err)
+
+	err = newSendTransactionError(protocol.SendTransactionResponse{
+		Status:              protocol.SendTransactionStatusError,
+		Hash:                "abcd",
+		ErrorResultXDR:      resultXdr,
+		DiagnosticEventsXDR: []string{eventXdr},
+	})
+
+	var txErr *TransactionError
+	require.True(t, errors.As(err, &txErr))
+	assert.True(t, txErr.Rejected)
+	assert.Equal(t, xdr.TransactionResultCodeTxFailed, txErr.Code)
+	require.NotNil(t, txErr.InvokeHostFunctionCode)
+	assert.Equal(t, xdr.InvokeHostFunctionResultCodeInvokeHostFunctionTrapped, *txErr.InvokeHostFunctionCode)
+
+	var contractErr *ContractError
+	require.True(t, errors.As(err, &contractErr))
+	assert.Equal(t, uint32(1205), contractErr.Code)
+	assert.Equal(t, strkey.MustEncode(strkey.VersionByteContract, contractId[:]), contractErr.ContractID)
+	assert.True(t, IsContractError(err, 1205))
+	assert.False(t, IsContractError(err, 1206))
+}
+
+func TestSimulationErrorFallsBackToMessage(t *testing.T) {
+	err := newSimulationError(protocol.SimulateTransactionResponse{
+		Error: "HostError: Error(Contract, #1205)\n\nEvent log (newest first): ...",
+	})
+	var simErr *SimulationError
+	require.True(t, errors.As(err, &simErr))
+	assert.True(t, IsContractError(err, 1205))
+}
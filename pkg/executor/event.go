@@ -3,10 +3,13 @@ package executor
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/stellar/go/xdr"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
 )
 
 type Event struct {
@@ -14,6 +17,32 @@ type Event struct {
 	Body   xdr.ScVal
 }
 
+// EventRecord is a decoded event along with the metadata getEvents reports
+// for it.
+type EventRecord struct {
+	Event
+	ID string
+	// Cursor is the event's position in the event stream, parsed from ID.
+	Cursor           protocol.Cursor
+	Type             string
+	ContractID       string
+	Ledger           uint32
+	LedgerClosedAt   time.Time
+	TransactionHash  string
+	TransactionIndex uint32
+	OperationIndex   uint32
+}
+
+// Name returns the event's first topic when it is a symbol, which by
+// convention names the event.
+func (r EventRecord) Name() (string, bool) {
+	if len(r.Topics) == 0 {
+		return "", false
+	}
+	sym, ok := r.Topics[0].GetSym()
+	return string(sym), ok
+}
+
 func EventCall(
 	rpc *soroban.RpcClient,
 	startLedger uint32,
@@ -21,6 +50,22 @@ func EventCall(
 	filters []protocol.EventFilter,
 	paginationOptions *protocol.PaginationOptions,
 
+) (
+	map[string][]Event,
+	*protocol.Cursor,
+	error,
+) {
+	return EventCallContext(context.Background(), rpc, startLedger, endLedger, filters, paginationOptions)
+}
+
+func EventCallContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	startLedger uint32,
+	endLedger uint32,
+	filters []protocol.EventFilter,
+	paginationOptions *protocol.PaginationOptions,
+
 ) (
 	map[string][]Event,
 	*protocol.Cursor,
@@ -31,8 +76,9 @@ func EventCall(
 		return nil, nil, fmt.Errorf("startLedger (%d) cannot be greater than endLedger (%d)", startLedger, endLedger)
 	}
 
-	events, err := rpc.GetEvents(
-		context.TODO(),
+	events, err := getEvents(
+		ctx,
+		rpc,
 		protocol.GetEventsRequest{
 			StartLedger: startLedger,
 			EndLedger:   endLedger,
@@ -47,44 +93,219 @@ func EventCall(
 
 	eventsResp := make(map[string][]Event)
 	for _, event := range events.Events {
+		formattedEvent, err := decodeEvent(event)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		eventsResp[event.ContractID] = append(eventsResp[event.ContractID], formattedEvent)
+	}
+
+	cursor := protocol.Cursor{
+		Ledger: events.LatestLedger,
+	}
+	if events.Cursor != "" {
+		cursor, err = protocol.ParseCursor(events.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return eventsResp, &cursor, nil
+}
+
+// EventRecordsCall is EventCall keeping each event's metadata. Events are
+// returned in stream order rather than grouped by contract.
+func EventRecordsCall(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	request protocol.GetEventsRequest,
+) (
+	[]EventRecord,
+	*protocol.Cursor,
+	error,
+) {
+	events, err := getEvents(ctx, rpc, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := make([]EventRecord, len(events.Events))
+	for idx, event := range events.Events {
+		records[idx], err = decodeEventRecord(event)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cursor := protocol.Cursor{
+		Ledger: events.LatestLedger,
+	}
+	if events.Cursor != "" {
+		cursor, err = protocol.ParseCursor(events.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return records, &cursor, nil
+}
+
+// getEvents runs request, split over several getEvents calls when it has more
+// filters than one call accepts. The pages are merged in stream order and cut
+// at the earliest of their cursors, so that resuming from the merged cursor
+// skips nothing any of the calls has yet to return.
+func getEvents(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	request protocol.GetEventsRequest,
+) (protocol.GetEventsResponse, error) {
+	requests := SplitEventsRequest(request)
+	if len(requests) == 1 {
+		return rpc.GetEvents(ctx, request)
+	}
+
+	type positioned struct {
+		cursor protocol.Cursor
+		info   protocol.EventInfo
+	}
 
-		topicsXdr := make([]xdr.ScVal, len(event.TopicXDR))
+	var merged protocol.GetEventsResponse
+	var events []positioned
+	var end *protocol.Cursor
+	seen := make(map[string]bool)
 
-		for idx, topic := range event.TopicXDR {
-			var topicXdr xdr.ScVal
-			err := xdr.SafeUnmarshalBase64(topic, &topicXdr)
+	for idx, req := range requests {
+		resp, err := rpc.GetEvents(ctx, req)
+		if err != nil {
+			return protocol.GetEventsResponse{}, err
+		}
+
+		if idx == 0 || resp.LatestLedger < merged.LatestLedger {
+			merged.LatestLedger = resp.LatestLedger
+			merged.LatestLedgerCloseTime = resp.LatestLedgerCloseTime
+		}
+		if idx == 0 || resp.OldestLedger > merged.OldestLedger {
+			merged.OldestLedger = resp.OldestLedger
+			merged.OldestLedgerCloseTime = resp.OldestLedgerCloseTime
+		}
+
+		for _, info := range resp.Events {
+			if seen[info.ID] {
+				continue
+			}
+			seen[info.ID] = true
+
+			cursor, err := protocol.ParseCursor(info.ID)
 			if err != nil {
-				return nil, nil, err
+				return protocol.GetEventsResponse{}, err
 			}
+			events = append(events, positioned{cursor: cursor, info: info})
+		}
 
-			topicsXdr[idx] = topicXdr
+		if resp.Cursor != "" {
+			cursor, err := protocol.ParseCursor(resp.Cursor)
+			if err != nil {
+				return protocol.GetEventsResponse{}, err
+			}
+			if end == nil || cursor.Cmp(*end) < 0 {
+				end = &cursor
+			}
 		}
+	}
+
+	slices.SortFunc(events, func(a, b positioned) int { return a.cursor.Cmp(b.cursor) })
+	if end != nil {
+		events = slices.DeleteFunc(events, func(event positioned) bool { return event.cursor.Cmp(*end) > 0 })
+	}
+	if request.Pagination != nil && request.Pagination.Limit > 0 && uint(len(events)) > request.Pagination.Limit {
+		events = events[:request.Pagination.Limit]
+		end = &events[len(events)-1].cursor
+	}
+
+	merged.Events = make([]protocol.EventInfo, len(events))
+	for idx, event := range events {
+		merged.Events[idx] = event.info
+	}
+	if end != nil {
+		merged.Cursor = end.String()
+	}
+
+	return merged, nil
+}
+
+func decodeEventRecord(info protocol.EventInfo) (EventRecord, error) {
+	cursor, err := protocol.ParseCursor(info.ID)
+	if err != nil {
+		return EventRecord{}, err
+	}
 
-		var eventBodyXdr xdr.ScVal
-		err := xdr.SafeUnmarshalBase64(event.ValueXDR, &eventBodyXdr)
+	event, err := decodeEvent(info)
+	if err != nil {
+		return EventRecord{}, err
+	}
+
+	record := EventRecord{
+		Event:            event,
+		ID:               info.ID,
+		Cursor:           cursor,
+		Type:             info.EventType,
+		ContractID:       info.ContractID,
+		Ledger:           uint32(info.Ledger),
+		TransactionHash:  info.TransactionHash,
+		TransactionIndex: info.TxIndex,
+		OperationIndex:   info.OpIndex,
+	}
+	if info.LedgerClosedAt != "" {
+		record.LedgerClosedAt, err = time.Parse(time.RFC3339, info.LedgerClosedAt)
 		if err != nil {
-			return nil, nil, err
+			return EventRecord{}, fmt.Errorf("error parsing close time of event %s: %w", info.ID, err)
 		}
+	}
 
-		formattedEvent := Event{
-			Topics: topicsXdr,
-			Body:   eventBodyXdr,
+	return record, nil
+}
+
+// decodeEvent decodes an event's topics and value from whichever of the XDR
+// and JSON forms the RPC returned.
+func decodeEvent(event protocol.EventInfo) (Event, error) {
+	if len(event.TopicXDR) == 0 && event.ValueXDR == "" && len(event.ValueJSON) > 0 {
+		topics, err := xdrjson.UnmarshalScVals(event.TopicJSON)
+		if err != nil {
+			return Event{}, fmt.Errorf("error decoding topics of event %s: %w", event.ID, err)
+		}
+		body, err := xdrjson.UnmarshalScVal(event.ValueJSON)
+		if err != nil {
+			return Event{}, fmt.Errorf("error decoding value of event %s: %w", event.ID, err)
 		}
+		return Event{Topics: topics, Body: body}, nil
+	}
+
+	topicsXdr := make([]xdr.ScVal, len(event.TopicXDR))
 
-		if _, ok := eventsResp[event.ContractID]; !ok {
-			eventsResp[event.ContractID] = []Event{}
+	for idx, topic := range event.TopicXDR {
+		var topicXdr xdr.ScVal
+		err := xdr.SafeUnmarshalBase64(topic, &topicXdr)
+		if err != nil {
+			return Event{}, fmt.Errorf("error decoding topic %d of event %s: %w", idx, event.ID, err)
 		}
-		eventsResp[event.ContractID] = append(eventsResp[event.ContractID], formattedEvent)
+
+		topicsXdr[idx] = topicXdr
 	}
 
-	cursor := protocol.Cursor{
-		Ledger: events.LatestLedger,
-		// Event:  uint32,
+	var eventBodyXdr xdr.ScVal
+	err := xdr.SafeUnmarshalBase64(event.ValueXDR, &eventBodyXdr)
+	if err != nil {
+		return Event{}, fmt.Errorf("error decoding value of event %s: %w", event.ID, err)
 	}
 
-	return eventsResp, &cursor, nil
+	return Event{
+		Topics: topicsXdr,
+		Body:   eventBodyXdr,
+	}, nil
 }
 
+// Deprecated: use AnySegment and AnySegments with EventFilterBuilder.
 func WildcardSwitch(exactlyOne bool) *string {
 	wildcard := "**"
 	if exactlyOne {
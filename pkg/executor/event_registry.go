@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EventDecoder turns an event into a typed value.
+type EventDecoder func(record EventRecord) (any, error)
+
+type eventDecoderKey struct {
+	contractID string
+	name       string
+}
+
+// EventRegistry maps events, keyed by contract and the symbol in their first
+// topic, to the decoders registered for them. It is safe for concurrent use.
+type EventRegistry struct {
+	mu       sync.RWMutex
+	decoders map[eventDecoderKey]EventDecoder
+}
+
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{decoders: make(map[eventDecoderKey]EventDecoder)}
+}
+
+// Register sets the decoder for events named name emitted by contractID. An
+// empty contractID matches events of that name from any contract that has no
+// decoder of its own. Registering the same pair again replaces the decoder.
+func (r *EventRegistry) Register(contractID string, name string, decoder EventDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decoders[eventDecoderKey{contractID: contractID, name: name}] = decoder
+}
+
+// RegisterEventDecoder is Register for a decoder producing a concrete type.
+func RegisterEventDecoder[T any](
+	registry *EventRegistry,
+	contractID string,
+	name string,
+	decode func(record EventRecord) (T, error),
+) {
+	registry.Register(contractID, name, func(record EventRecord) (any, error) {
+		return decode(record)
+	})
+}
+
+// Decode runs the decoder registered for record. It reports false, without
+// an error, when no decoder matches.
+func (r *EventRegistry) Decode(record EventRecord) (any, bool, error) {
+	name, ok := record.Name()
+	if !ok {
+		return nil, false, nil
+	}
+
+	r.mu.RLock()
+	decoder, ok := r.decoders[eventDecoderKey{contractID: record.ContractID, name: name}]
+	if !ok {
+		decoder, ok = r.decoders[eventDecoderKey{name: name}]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, err := decoder(record)
+	if err != nil {
+		return nil, true, fmt.Errorf("error decoding %s event %s: %w", name, record.ID, err)
+	}
+	return value, true, nil
+}
@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestEventRegistryDecode(t *testing.T) {
+	registry := NewEventRegistry()
+	RegisterEventDecoder(registry, "", "transfer", func(record EventRecord) (string, error) {
+		return "any " + record.ContractID, nil
+	})
+	RegisterEventDecoder(registry, "CPOOL", "transfer", func(record EventRecord) (string, error) {
+		return "pool", nil
+	})
+	RegisterEventDecoder(registry, "CPOOL", "broken", func(record EventRecord) (int, error) {
+		return 0, errors.New("boom")
+	})
+
+	record := func(contractID string, name xdr.ScSymbol) EventRecord {
+		return EventRecord{
+			Event:      Event{Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvSymbol, Sym: &name}}},
+			ContractID: contractID,
+		}
+	}
+
+	value, ok, err := registry.Decode(record("CPOOL", "transfer"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "pool", value)
+
+	value, ok, err = registry.Decode(record("CTOKEN", "transfer"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "any CTOKEN", value)
+
+	_, ok, err = registry.Decode(record("CPOOL", "mint"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = registry.Decode(record("CPOOL", "broken"))
+	assert.True(t, ok)
+	assert.ErrorContains(t, err, "boom")
+
+	_, ok, err = registry.Decode(EventRecord{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDecodeEventRecord(t *testing.T) {
+	value, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvVoid})
+	require.NoError(t, err)
+	cursor := protocol.Cursor{Ledger: 10, Tx: 2, Op: 1, Event: 3}
+
+	record, err := decodeEventRecord(protocol.EventInfo{
+		EventType:       protocol.EventTypeContract,
+		Ledger:          10,
+		LedgerClosedAt:  "2025-01-02T03:04:05Z",
+		ContractID:      "CPOOL",
+		ID:              cursor.String(),
+		OpIndex:         1,
+		TxIndex:         2,
+		TransactionHash: "abcd",
+		ValueXDR:        value,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, cursor, record.Cursor)
+	assert.Equal(t, uint32(10), record.Ledger)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), record.LedgerClosedAt)
+	assert.Equal(t, uint32(1), record.OperationIndex)
+	assert.Equal(t, uint32(2), record.TransactionIndex)
+	assert.Equal(t, "abcd", record.TransactionHash)
+}
@@ -0,0 +1,25 @@
+package executor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestDecodeEventJSON(t *testing.T) {
+	event, err := decodeEvent(protocol.EventInfo{
+		ID:        "0000000042949677056-0000000001",
+		TopicJSON: []json.RawMessage{json.RawMessage(`{"symbol":"transfer"}`)},
+		ValueJSON: json.RawMessage(`{"i128":"100"}`),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, event.Topics, 1)
+	assert.Equal(t, xdr.ScSymbol("transfer"), event.Topics[0].MustSym())
+	assert.Equal(t, xdr.Uint64(100), event.Body.MustI128().Lo)
+}
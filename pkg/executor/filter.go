@@ -0,0 +1,186 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// Wildcard is a topic segment matching any value.
+type Wildcard string
+
+const (
+	// AnySegment matches exactly one topic.
+	AnySegment Wildcard = protocol.WildCardExactOne
+	// AnySegments matches any number of trailing topics. It may only be the
+	// last segment of a topic filter.
+	AnySegments Wildcard = protocol.WildCardZeroOrMore
+)
+
+// EventFilterBuilder builds getEvents filters. Unlike protocol.EventFilter it
+// takes any number of contract IDs and topic filters; Build splits them into
+// as many filters as the RPC limits require.
+type EventFilterBuilder struct {
+	eventTypes  protocol.EventTypeSet
+	contractIDs []string
+	topics      []protocol.TopicFilter
+	err         error
+}
+
+func NewEventFilter() *EventFilterBuilder {
+	return &EventFilterBuilder{}
+}
+
+// Contracts adds contract IDs to match. Events from any of them match.
+func (b *EventFilterBuilder) Contracts(contractIDs ...string) *EventFilterBuilder {
+	b.contractIDs = append(b.contractIDs, contractIDs...)
+	return b
+}
+
+// Type restricts the filter to protocol.EventTypeSystem or
+// protocol.EventTypeContract events.
+func (b *EventFilterBuilder) Type(eventTypes ...string) *EventFilterBuilder {
+	if b.eventTypes == nil {
+		b.eventTypes = protocol.EventTypeSet{}
+	}
+	for _, eventType := range eventTypes {
+		b.eventTypes[eventType] = nil
+	}
+	return b
+}
+
+// Topic adds a topic filter, one segment per value. Each segment is either a
+// Wildcard or a value accepted by TopicScVal. Events matching any of the
+// topic filters match.
+func (b *EventFilterBuilder) Topic(segments ...any) *EventFilterBuilder {
+	topic := make(protocol.TopicFilter, len(segments))
+	for idx, segment := range segments {
+		if wildcard, ok := segment.(Wildcard); ok {
+			w := string(wildcard)
+			topic[idx] = protocol.SegmentFilter{Wildcard: &w}
+			continue
+		}
+
+		val, err := TopicScVal(segment)
+		if err != nil {
+			b.err = errors.Join(b.err, fmt.Errorf("topic %d segment %d: %w", len(b.topics)+1, idx+1, err))
+			return b
+		}
+		topic[idx] = protocol.SegmentFilter{ScVal: &val}
+	}
+
+	b.topics = append(b.topics, topic)
+	return b
+}
+
+// Build returns the filters, split so that none exceeds MaxContractIDsLimit
+// contract IDs or MaxTopicsLimit topic filters. Each is checked with
+// EventFilter.Valid. There may be more than MaxFiltersLimit of them;
+// SplitEventsRequest spreads those over several requests.
+func (b *EventFilterBuilder) Build() ([]protocol.EventFilter, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	contractChunks := chunk(b.contractIDs, protocol.MaxContractIDsLimit)
+	topicChunks := chunk(b.topics, protocol.MaxTopicsLimit)
+
+	filters := make([]protocol.EventFilter, 0, len(contractChunks)*len(topicChunks))
+	for _, contractIDs := range contractChunks {
+		for _, topics := range topicChunks {
+			filter := protocol.EventFilter{
+				EventType:   b.eventTypes,
+				ContractIDs: contractIDs,
+				Topics:      topics,
+			}
+			if err := filter.Valid(); err != nil {
+				return nil, fmt.Errorf("invalid event filter: %w", err)
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	return filters, nil
+}
+
+// SplitEventsRequest spreads request's filters over as many requests as
+// MaxFiltersLimit requires. A request within the limit is returned as is.
+func SplitEventsRequest(request protocol.GetEventsRequest) []protocol.GetEventsRequest {
+	filterChunks := chunk(request.Filters, protocol.MaxFiltersLimit)
+
+	requests := make([]protocol.GetEventsRequest, len(filterChunks))
+	for idx, filters := range filterChunks {
+		requests[idx] = request
+		requests[idx].Filters = filters
+	}
+	return requests
+}
+
+// TopicScVal converts a Go value to the ScVal a topic segment matches:
+//
+//   - xdr.ScVal and *xdr.ScVal are used as is
+//   - helpers.Symbol and xdr.ScSymbol become symbols, string becomes a string
+//   - helpers.Address and xdr.ScAddress become addresses
+//   - *big.Int and xdr.Int128Parts become i128
+//   - bool, int32, uint32, int64, uint64 and []byte map to the matching type
+func TopicScVal(value any) (xdr.ScVal, error) {
+	switch v := value.(type) {
+	case xdr.ScVal:
+		return v, nil
+	case *xdr.ScVal:
+		if v == nil {
+			return xdr.ScVal{}, errors.New("nil ScVal")
+		}
+		return *v, nil
+	case helpers.Symbol:
+		return v.MarshalScVal()
+	case xdr.ScSymbol:
+		return xdr.NewScVal(xdr.ScValTypeScvSymbol, v)
+	case string:
+		return xdr.NewScVal(xdr.ScValTypeScvString, xdr.ScString(v))
+	case helpers.Address:
+		return v.MarshalScVal()
+	case xdr.ScAddress:
+		return xdr.NewScVal(xdr.ScValTypeScvAddress, v)
+	case *big.Int:
+		i128, err := helpers.BigIntToI128(v)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvI128, i128)
+	case xdr.Int128Parts:
+		return xdr.NewScVal(xdr.ScValTypeScvI128, v)
+	case bool:
+		return xdr.NewScVal(xdr.ScValTypeScvBool, v)
+	case int32:
+		return xdr.NewScVal(xdr.ScValTypeScvI32, xdr.Int32(v))
+	case uint32:
+		return xdr.NewScVal(xdr.ScValTypeScvU32, xdr.Uint32(v))
+	case int64:
+		return xdr.NewScVal(xdr.ScValTypeScvI64, xdr.Int64(v))
+	case uint64:
+		return xdr.NewScVal(xdr.ScValTypeScvU64, xdr.Uint64(v))
+	case []byte:
+		return xdr.NewScVal(xdr.ScValTypeScvBytes, xdr.ScBytes(v))
+	default:
+		return xdr.ScVal{}, fmt.Errorf("unsupported topic value of type %T", value)
+	}
+}
+
+// chunk splits items into slices of at most size items. It always returns at
+// least one, possibly empty, chunk.
+func chunk[T any](items []T, size int) [][]T {
+	if len(items) <= size {
+		return [][]T{items}
+	}
+
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		chunks = append(chunks, items[start:min(start+size, len(items))])
+	}
+	return chunks
+}
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func testContractIDs(n int) []string {
+	ids := make([]string, n)
+	for idx := range ids {
+		ids[idx] = strkey.MustEncode(strkey.VersionByteContract, append(make([]byte, 31), byte(idx)))
+	}
+	return ids
+}
+
+func TestEventFilterBuilder(t *testing.T) {
+	user := keypair.MustRandom().Address()
+	contracts := testContractIDs(7)
+
+	builder := NewEventFilter().
+		Contracts(contracts...).
+		Type(protocol.EventTypeContract)
+	for idx := 0; idx < 6; idx++ {
+		builder.Topic(helpers.Symbol("transfer"), helpers.Address(user), AnySegment, big.NewInt(int64(idx)), AnySegments)
+	}
+
+	filters, err := builder.Build()
+	require.NoError(t, err)
+	require.Len(t, filters, 4)
+	assert.Len(t, filters[0].ContractIDs, 5)
+	assert.Len(t, filters[0].Topics, 5)
+	assert.Len(t, filters[3].ContractIDs, 2)
+	assert.Len(t, filters[3].Topics, 1)
+
+	topics := []xdr.ScVal{
+		mustTopic(t, helpers.Symbol("transfer")),
+		mustTopic(t, helpers.Address(user)),
+		mustTopic(t, "anything"),
+		mustTopic(t, big.NewInt(5)),
+	}
+	assert.True(t, filters[3].Topics[0].Matches(topics))
+	assert.False(t, filters[0].Topics[0].Matches(topics))
+
+	_, err = NewEventFilter().Topic(AnySegments, helpers.Symbol("transfer")).Build()
+	assert.ErrorContains(t, err, "only allowed as the last segment")
+
+	_, err = NewEventFilter().Topic(3.5).Build()
+	assert.ErrorContains(t, err, "unsupported topic value of type float64")
+
+	requests := SplitEventsRequest(protocol.GetEventsRequest{StartLedger: 1, Filters: make([]protocol.EventFilter, 7)})
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0].Filters, 5)
+	assert.Len(t, requests[1].Filters, 2)
+	assert.Equal(t, uint32(1), requests[1].StartLedger)
+}
+
+func TestEventRecordsCallMergesSplitRequests(t *testing.T) {
+	events := func(cursor protocol.Cursor, infos ...protocol.Cursor) string {
+		encoded := ""
+		for idx, info := range infos {
+			if idx > 0 {
+				encoded += ","
+			}
+			encoded += testEventInfo(t, info)
+		}
+		return fmt.Sprintf(`{"events":[%s],"cursor":%q,"latestLedger":20,"oldestLedger":5}`, encoded, cursor.String())
+	}
+	rpc := newEventsRPC(t, `{}`, func(params getEventsParams) string {
+		if len(params.Filters) == protocol.MaxFiltersLimit {
+			return events(protocol.Cursor{Ledger: 12}, protocol.Cursor{Ledger: 10}, protocol.Cursor{Ledger: 12})
+		}
+		return events(protocol.Cursor{Ledger: 14}, protocol.Cursor{Ledger: 11}, protocol.Cursor{Ledger: 14})
+	})
+
+	filters := make([]protocol.EventFilter, 6)
+	for idx, contractID := range testContractIDs(6) {
+		filters[idx].ContractIDs = []string{contractID}
+	}
+
+	records, cursor, err := EventRecordsCall(context.Background(), rpc, protocol.GetEventsRequest{StartLedger: 10, Filters: filters})
+	require.NoError(t, err)
+
+	ledgers := make([]uint32, len(records))
+	for idx, record := range records {
+		ledgers[idx] = record.Ledger
+	}
+	assert.Equal(t, []uint32{10, 11, 12}, ledgers)
+	assert.Equal(t, protocol.Cursor{Ledger: 12}, *cursor)
+}
+
+func mustTopic(t *testing.T, value any) xdr.ScVal {
+	val, err := TopicScVal(value)
+	require.NoError(t, err)
+	return val
+}
@@ -0,0 +1,152 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// newJSONServer answers each method with a canned result written in the
+// JSON form Stellar-RPC returns for xdrFormat=json, and fails every request
+// that does not ask for it.
+func newJSONServer(t *testing.T, results map[string]string) (*mockRPC, *soroban.RpcClient) {
+	jsonResults := make(map[string]rpcResult, len(results))
+	for method, result := range results {
+		jsonResults[method] = func(req rpcRequest) (string, error) {
+			var params struct {
+				Format string `json:"xdrFormat"`
+			}
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			if params.Format != protocol.FormatJSON {
+				return "", fmt.Errorf("%s requested xdrFormat %q", method, params.Format)
+			}
+			return result, nil
+		}
+	}
+	return newMockRPC(t, jsonResults, soroban.WithXDRFormat(protocol.FormatJSON))
+}
+
+var (
+	jsonTestContractId = xdr.ContractId{7}
+	jsonTestContract   = strkey.MustEncode(strkey.VersionByteContract, jsonTestContractId[:])
+	// jsonTestErrorEvent is the diagnostic event the host logs when the
+	// contract fails with error code 1205.
+	jsonTestErrorEvent = `{"in_successful_contract_call":false,"event":{"ext":"v0","contract_id":"` + jsonTestContract +
+		`","type_":"diagnostic","body":{"v0":{"topics":[{"symbol":"error"},{"error":{"contract":1205}}],"data":"void"}}}}`
+)
+
+func TestInvokeContractCallJSON(t *testing.T) {
+	instanceKey := `{"contract_data":{"contract":"` + jsonTestContract + `","key":"ledger_key_contract_instance","durability":"persistent"}}`
+	server, rpc := newJSONServer(t, map[string]string{
+		protocol.SimulateTransactionMethodName: `{
+			"transactionDataJson":{"ext":"v0","resources":{"footprint":{"read_only":[` + instanceKey + `],"read_write":[]},
+				"instructions":2000,"disk_read_bytes":100,"write_bytes":0},"resource_fee":"0"},
+			"minResourceFee":"1000","results":[{"authJson":[],"returnValueJson":{"u32":9}}],"latestLedger":6}`,
+		protocol.SendTransactionMethodName: `{"status":"PENDING","hash":"tx1"}`,
+		protocol.GetTransactionMethodName: `{"status":"SUCCESS","ledger":7,"resultMetaJson":{"v4":{"ext":"v0",
+			"tx_changes_before":[],"operations":[{"ext":"v0","changes":[],"events":[{"ext":"v0","contract_id":"` + jsonTestContract +
+			`","type_":"contract","body":{"v0":{"topics":[{"symbol":"mint"}],"data":{"i128":"5"}}}}]}],
+			"tx_changes_after":[],"soroban_meta":{"ext":"v0","return_value":{"u32":9}},"events":[],"diagnostic_events":[]}}}`,
+	})
+
+	signer := keypair.MustRandom()
+	source := txnbuild.NewSimpleAccount(signer.Address(), 10)
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &jsonTestContractId}
+	opts := SubmitOptions{BaseFee: 100, PollInterval: time.Millisecond}
+
+	returnValue, err := SimulateContractCallContext(context.Background(), rpc, contract, &source, nil, "balance")
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Uint32(9), returnValue.MustU32())
+
+	result, err := InvokeContractCall(
+		context.Background(), rpc, contract, &source, nil, "balance",
+		network.TestNetworkPassphrase, []*keypair.Full{signer}, opts,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), result.Ledger)
+	require.NotNil(t, result.ReturnValue)
+	assert.Equal(t, xdr.Uint32(9), result.ReturnValue.MustU32())
+	require.Len(t, result.Events, 1)
+	assert.Equal(t, xdr.ScSymbol("mint"), result.Events[0].Body.V0.Topics[0].MustSym())
+
+	envelopes := server.sent()
+	require.Len(t, envelopes, 1)
+	sorobanData := envelopes[0].V1.Tx.Ext.SorobanData
+	require.NotNil(t, sorobanData)
+	assert.Equal(t, xdr.Int64(1000), sorobanData.ResourceFee)
+	assert.Equal(t, xdr.Uint32(2000), sorobanData.Resources.Instructions)
+	require.Len(t, sorobanData.Resources.Footprint.ReadOnly, 1)
+	assert.Equal(t, xdr.ScValTypeScvLedgerKeyContractInstance, sorobanData.Resources.Footprint.ReadOnly[0].ContractData.Key.Type)
+}
+
+func TestSimulationErrorJSON(t *testing.T) {
+	_, rpc := newJSONServer(t, map[string]string{
+		protocol.SimulateTransactionMethodName: `{"error":"HostError: Error(Contract, #1205)","eventsJson":[` +
+			jsonTestErrorEvent + `],"latestLedger":6}`,
+	})
+
+	source := txnbuild.NewSimpleAccount(keypair.MustRandom().Address(), 10)
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &jsonTestContractId}
+	_, err := SimulateContractCallContext(context.Background(), rpc, contract, &source, nil, "balance")
+
+	var simErr *SimulationError
+	require.True(t, errors.As(err, &simErr))
+	require.Len(t, simErr.DiagnosticEvents, 1)
+	var contractErr *ContractError
+	require.True(t, errors.As(err, &contractErr))
+	assert.Equal(t, uint32(1205), contractErr.Code)
+	assert.Equal(t, jsonTestContract, contractErr.ContractID)
+}
+
+func TestWaitForTransactionFailedJSON(t *testing.T) {
+	_, rpc := newJSONServer(t, map[string]string{
+		protocol.GetTransactionMethodName: `{"status":"FAILED","ledger":7,
+			"resultJson":{"fee_charged":"100","result":{"tx_failed":[{"op_inner":{"invoke_host_function":"trapped"}}]},"ext":"v0"},
+			"diagnosticEventsJson":[` + jsonTestErrorEvent + `]}`,
+	})
+
+	_, err := WaitForTransaction(context.Background(), rpc, "abcd", 0, SubmitOptions{PollInterval: time.Millisecond})
+
+	var txErr *TransactionError
+	require.True(t, errors.As(err, &txErr))
+	assert.False(t, txErr.Rejected)
+	assert.Equal(t, xdr.TransactionResultCodeTxFailed, txErr.Code)
+	require.NotNil(t, txErr.InvokeHostFunctionCode)
+	assert.Equal(t, xdr.InvokeHostFunctionResultCodeInvokeHostFunctionTrapped, *txErr.InvokeHostFunctionCode)
+	assert.True(t, IsContractError(err, 1205))
+}
+
+func TestLedgerEntriesCallJSON(t *testing.T) {
+	key := testContractDataKey("counter", xdr.ContractDataDurabilityPersistent)
+	missing := testContractDataKey("missing", xdr.ContractDataDurabilityPersistent)
+	contract := strkey.MustEncode(strkey.VersionByteContract, key.ContractData.Contract.ContractId[:])
+	_, rpc := newJSONServer(t, map[string]string{
+		protocol.GetLedgerEntriesMethodName: `{"entries":[{
+			"keyJson":{"contract_data":{"contract":"` + contract + `","key":{"symbol":"counter"},"durability":"persistent"}},
+			"dataJson":{"contract_data":{"ext":"v0","contract":"` + contract + `","key":{"symbol":"counter"},"durability":"persistent","val":{"u64":"42"}}},
+			"lastModifiedLedgerSeq":3,"liveUntilLedgerSeq":500}],"latestLedger":100}`,
+	})
+
+	result, err := LedgerEntriesCall(context.Background(), rpc, []xdr.LedgerKey{key, missing}, LedgerEntriesOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []xdr.LedgerKey{missing}, result.Missing)
+	require.Len(t, result.Found, 1)
+	assert.Equal(t, key, result.Found[0].Key)
+	assert.Equal(t, xdr.Uint64(42), result.Found[0].Data.MustContractData().Val.MustU64())
+	assert.Equal(t, uint32(100), result.LatestLedger)
+}
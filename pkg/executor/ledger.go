@@ -3,63 +3,273 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/stellar/go/xdr"
 	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
 )
 
+const (
+	// MaxLedgerEntriesKeys is the most keys Stellar-RPC accepts in a single
+	// getLedgerEntries request.
+	MaxLedgerEntriesKeys          = 200
+	DefaultLedgerEntriesChunkSize = MaxLedgerEntriesKeys
+	DefaultLedgerEntriesWorkers   = 4
+)
+
+// LedgerEntry is a ledger entry together with its key and TTL metadata.
+type LedgerEntry struct {
+	Key                xdr.LedgerKey
+	Data               xdr.LedgerEntryData
+	LastModifiedLedger uint32
+	// LiveUntilLedgerSeq is the last ledger the entry is live in. It is nil
+	// for entries without a TTL, such as accounts and trustlines.
+	LiveUntilLedgerSeq *uint32
+}
+
+// TTL returns how many ledgers after latestLedger the entry stays live, and
+// false if the entry has no TTL. Archived entries report a TTL of zero.
+func (e LedgerEntry) TTL(latestLedger uint32) (uint32, bool) {
+	if e.LiveUntilLedgerSeq == nil {
+		return 0, false
+	}
+	if *e.LiveUntilLedgerSeq <= latestLedger {
+		return 0, true
+	}
+	return *e.LiveUntilLedgerSeq - latestLedger, true
+}
+
+// LedgerEntriesOptions controls how large key sets are split across
+// getLedgerEntries requests.
+type LedgerEntriesOptions struct {
+	// ChunkSize is the most keys sent in one request. It is capped at
+	// MaxLedgerEntriesKeys.
+	ChunkSize int
+	// Workers is the most requests in flight at once.
+	Workers int
+}
+
+func DefaultLedgerEntriesOptions() LedgerEntriesOptions {
+	return LedgerEntriesOptions{
+		ChunkSize: DefaultLedgerEntriesChunkSize,
+		Workers:   DefaultLedgerEntriesWorkers,
+	}
+}
+
+// LedgerEntriesResult holds the entries found for a set of keys and the keys
+// the RPC did not return. Entries are matched to keys by their XDR encoding,
+// so the order the RPC returns them in does not matter.
+type LedgerEntriesResult struct {
+	// Found holds the entries that exist, once per key, in the order their
+	// keys were requested.
+	Found []LedgerEntry
+	// Missing holds the requested keys that have no entry, once per key, in
+	// request order.
+	Missing []xdr.LedgerKey
+	// LatestLedger is the oldest latest ledger reported across the chunked
+	// requests, so every entry is at least as fresh as it.
+	LatestLedger uint32
+
+	index map[string]int
+}
+
+// Get returns the entry for ledgerKey, and false if it was not found.
+func (r *LedgerEntriesResult) Get(ledgerKey xdr.LedgerKey) (LedgerEntry, bool) {
+	encodedKey, err := ledgerKey.MarshalBinaryBase64()
+	if err != nil {
+		return LedgerEntry{}, false
+	}
+	idx, ok := r.index[encodedKey]
+	if !ok {
+		return LedgerEntry{}, false
+	}
+	return r.Found[idx], true
+}
+
+// LedgerEntryCall fetches ledgerKeys with the default options.
+//
+// Deprecated: contractAddress is unused. Use LedgerEntriesCall.
 func LedgerEntryCall(
 	rpc *soroban.RpcClient,
 	contractAddress xdr.ScAddress,
 	ledgerKeys []xdr.LedgerKey,
-) (map[xdr.LedgerKey]xdr.LedgerEntryData, error) {
+) (*LedgerEntriesResult, error) {
+	return LedgerEntryCallContext(context.Background(), rpc, contractAddress, ledgerKeys)
+}
+
+// LedgerEntryCallContext fetches ledgerKeys with the default options.
+//
+// Deprecated: contractAddress is unused. Use LedgerEntriesCall.
+func LedgerEntryCallContext(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	ledgerKeys []xdr.LedgerKey,
+) (*LedgerEntriesResult, error) {
+	return LedgerEntriesCall(ctx, rpc, ledgerKeys, DefaultLedgerEntriesOptions())
+}
+
+// LedgerEntriesCall fetches any number of ledger keys. Key sets larger than
+// opts.ChunkSize are split into chunks that are requested concurrently by up
+// to opts.Workers workers, and the results are merged.
+func LedgerEntriesCall(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	ledgerKeys []xdr.LedgerKey,
+	opts LedgerEntriesOptions,
+) (*LedgerEntriesResult, error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 || chunkSize > MaxLedgerEntriesKeys {
+		chunkSize = MaxLedgerEntriesKeys
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 1
+	}
 
-	keys := make([]string, len(ledgerKeys))
+	// Repeated keys are requested once and reported once, where they first
+	// appear.
+	keys := make([]string, 0, len(ledgerKeys))
+	uniqueKeys := make([]xdr.LedgerKey, 0, len(ledgerKeys))
+	seen := make(map[string]bool, len(ledgerKeys))
 	for idx, ledgerKey := range ledgerKeys {
 		encodedKey, err := ledgerKey.MarshalBinaryBase64()
 		if err != nil {
 			return nil, fmt.Errorf("error encoding ledger key at index %d: %w", idx, err)
 		}
 
-		keys[idx] = encodedKey
+		if seen[encodedKey] {
+			continue
+		}
+		seen[encodedKey] = true
+		keys = append(keys, encodedKey)
+		uniqueKeys = append(uniqueKeys, ledgerKey)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mx       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		found    = make(map[string]LedgerEntry, len(keys))
+		sem      = make(chan struct{}, workers)
+		latest   = make([]uint32, 0, len(keys)/chunkSize+1)
+	)
+
+	for start := 0; start < len(keys); start += chunkSize {
+		end := min(start+chunkSize, len(keys))
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			entries, latestLedger, err := ledgerEntriesChunk(ctx, rpc, keys[start:end])
+
+			mx.Lock()
+			defer mx.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("error fetching ledger keys %d to %d: %w", start, end-1, err)
+					cancel()
+				}
+				return
+			}
+			for key, entry := range entries {
+				found[key] = entry
+			}
+			latest = append(latest, latestLedger)
+		}(start, end)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
 	}
 
+	result := &LedgerEntriesResult{index: make(map[string]int, len(found))}
+	for idx, key := range keys {
+		entry, ok := found[key]
+		if !ok {
+			result.Missing = append(result.Missing, uniqueKeys[idx])
+			continue
+		}
+		result.index[key] = len(result.Found)
+		result.Found = append(result.Found, entry)
+	}
+	if len(latest) > 0 {
+		result.LatestLedger = latest[0]
+		for _, latestLedger := range latest[1:] {
+			result.LatestLedger = min(result.LatestLedger, latestLedger)
+		}
+	}
+
+	return result, nil
+}
+
+// ledgerEntriesChunk fetches one request's worth of keys and returns the
+// entries it found keyed by their encoded ledger key.
+func ledgerEntriesChunk(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	keys []string,
+) (map[string]LedgerEntry, uint32, error) {
+
 	resp, err := rpc.GetLedgerEntries(
-		context.TODO(),
-		protocol.GetLedgerEntriesRequest{
-			Keys: keys,
-		},
+		ctx,
+		protocol.GetLedgerEntriesRequest{Keys: keys},
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	requested := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		requested[key] = true
 	}
 
-	result := make(map[xdr.LedgerKey]xdr.LedgerEntryData)
+	result := make(map[string]LedgerEntry, len(resp.Entries))
 	for idx, entry := range resp.Entries {
 		var ledgerKeyXdr xdr.LedgerKey
 
-		err := xdr.SafeUnmarshalBase64(entry.KeyXDR, &ledgerKeyXdr)
+		err := xdrjson.Decode(entry.KeyXDR, entry.KeyJSON, &ledgerKeyXdr)
 		if err != nil {
-			return nil, err
+			return nil, 0, fmt.Errorf("error unmarshaling entry key at index %d: %w", idx, err)
 		}
 
-		if ledgerKeyXdr.Equals(ledgerKeys[idx]) {
+		// Re-encode the key so it compares equal to the requested encoding
+		// whatever form the RPC returned it in.
+		encodedKey, err := ledgerKeyXdr.MarshalBinaryBase64()
+		if err != nil {
+			return nil, 0, fmt.Errorf("error encoding entry key at index %d: %w", idx, err)
+		}
 
-			var bodyXdr xdr.LedgerEntryData
+		if !requested[encodedKey] {
+			return nil, 0, fmt.Errorf("unexpected ledger entry for key %s at index %d", encodedKey, idx)
+		}
 
-			err := xdr.SafeUnmarshalBase64(entry.DataXDR, &bodyXdr)
+		var bodyXdr xdr.LedgerEntryData
 
-			if err != nil {
-				return nil, fmt.Errorf("error unmarshaling entry data at index %d: %w", idx, err)
-			}
+		err = xdrjson.Decode(entry.DataXDR, entry.DataJSON, &bodyXdr)
 
-			result[ledgerKeyXdr] = bodyXdr
+		if err != nil {
+			return nil, 0, fmt.Errorf("error unmarshaling entry data at index %d: %w", idx, err)
 		}
 
+		result[encodedKey] = LedgerEntry{
+			Key:                ledgerKeyXdr,
+			Data:               bodyXdr,
+			LastModifiedLedger: entry.LastModifiedLedger,
+			LiveUntilLedgerSeq: entry.LiveUntilLedgerSeq,
+		}
 	}
 
-	return result, nil
+	return result, resp.LatestLedger, nil
 
 }
@@ -0,0 +1,130 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func testAccountKey(t *testing.T, idx int) xdr.LedgerKey {
+	var seed xdr.Uint256
+	seed[0], seed[1] = byte(idx>>8), byte(idx)
+	accountID, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, seed)
+	require.NoError(t, err)
+	var key xdr.LedgerKey
+	require.NoError(t, key.SetAccount(accountID))
+	return key
+}
+
+func TestLedgerEntriesCallChunks(t *testing.T) {
+	var requests atomic.Int32
+	_, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.GetLedgerEntriesMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.GetLedgerEntriesRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			requests.Add(1)
+
+			// Return every key except the first of each chunk in reverse
+			// order, with the latest ledger depending on the chunk size so
+			// the minimum is observable.
+			var entries []map[string]any
+			for idx := len(params.Keys) - 1; idx > 0; idx-- {
+				key := params.Keys[idx]
+				var ledgerKey xdr.LedgerKey
+				if err := xdr.SafeUnmarshalBase64(key, &ledgerKey); err != nil {
+					return "", err
+				}
+				dataXdr, err := xdr.MarshalBase64(xdr.LedgerEntryData{
+					Type:    xdr.LedgerEntryTypeAccount,
+					Account: &xdr.AccountEntry{AccountId: ledgerKey.Account.AccountId},
+				})
+				if err != nil {
+					return "", err
+				}
+				entries = append(entries, map[string]any{
+					"key":                   key,
+					"xdr":                   dataXdr,
+					"lastModifiedLedgerSeq": 1,
+				})
+			}
+			result, err := json.Marshal(map[string]any{
+				"entries":      entries,
+				"latestLedger": 100 + len(params.Keys),
+			})
+			return string(result), err
+		},
+	})
+
+	keys := make([]xdr.LedgerKey, 25)
+	for idx := range keys {
+		keys[idx] = testAccountKey(t, idx)
+	}
+
+	result, err := LedgerEntriesCall(context.Background(), rpc, keys, LedgerEntriesOptions{ChunkSize: 10, Workers: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+	require.Len(t, result.Found, 22)
+	assert.True(t, result.Found[0].Key.Equals(keys[1]))
+	assert.True(t, result.Found[21].Key.Equals(keys[24]))
+	for _, entry := range result.Found {
+		assert.True(t, entry.Data.Account.AccountId.Equals(entry.Key.Account.AccountId))
+	}
+	entry, ok := result.Get(keys[11])
+	require.True(t, ok)
+	assert.True(t, entry.Key.Equals(keys[11]))
+	_, ok = result.Get(keys[10])
+	assert.False(t, ok)
+	require.Len(t, result.Missing, 3)
+	assert.True(t, result.Missing[0].Equals(keys[0]))
+	assert.True(t, result.Missing[1].Equals(keys[10]))
+	assert.True(t, result.Missing[2].Equals(keys[20]))
+	assert.Equal(t, uint32(105), result.LatestLedger)
+}
+
+func TestLedgerEntriesCallRepeatedKeys(t *testing.T) {
+	keys := []xdr.LedgerKey{testAccountKey(t, 0), testAccountKey(t, 1), testAccountKey(t, 0), testAccountKey(t, 1), testAccountKey(t, 2)}
+	foundKey, err := keys[1].MarshalBinaryBase64()
+	require.NoError(t, err)
+	dataXdr, err := xdr.MarshalBase64(xdr.LedgerEntryData{
+		Type:    xdr.LedgerEntryTypeAccount,
+		Account: &xdr.AccountEntry{AccountId: keys[1].Account.AccountId},
+	})
+	require.NoError(t, err)
+
+	var requested atomic.Int32
+	server, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.GetLedgerEntriesMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.GetLedgerEntriesRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			requested.Add(int32(len(params.Keys)))
+			if !slices.Contains(params.Keys, foundKey) {
+				return `{"entries":[],"latestLedger":10}`, nil
+			}
+			return fmt.Sprintf(
+				`{"entries":[{"key":%q,"xdr":%q,"lastModifiedLedgerSeq":1}],"latestLedger":10}`, foundKey, dataXdr,
+			), nil
+		},
+	})
+
+	// Deduplicated, the five keys fit in two chunks of two.
+	result, err := LedgerEntriesCall(context.Background(), rpc, keys, LedgerEntriesOptions{ChunkSize: 2, Workers: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requested.Load())
+	assert.Len(t, server.calls(), 2)
+	require.Len(t, result.Found, 1)
+	assert.True(t, result.Found[0].Key.Equals(keys[1]))
+	assert.Equal(t, []xdr.LedgerKey{keys[0], keys[4]}, result.Missing)
+}
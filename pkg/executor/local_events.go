@@ -0,0 +1,279 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
+)
+
+// zstdMagic starts every zstd frame, such as the compressed ledger files
+// galexie exports.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// LedgerEvents returns the contract and system events in meta that match
+// request's filters, in the order and with the IDs getEvents gives them.
+// Only request's filters and format are used; ledger ranges and pagination
+// are left to LocalEvents.
+func LedgerEvents(meta xdr.LedgerCloseMeta, request protocol.GetEventsRequest) ([]protocol.EventInfo, error) {
+	sequence := meta.LedgerSequence()
+	closedAt := meta.ClosedAt().UTC().Format(time.RFC3339)
+
+	var events []protocol.EventInfo
+	for idx := 0; idx < meta.CountTransactions(); idx++ {
+		if !meta.TransactionResultPair(idx).Successful() {
+			continue
+		}
+
+		txMeta := meta.TxApplyProcessing(idx)
+		hash := meta.TransactionHash(idx)
+
+		// Meta before v4 keeps all of a transaction's events together, and
+		// getEvents files them under operation 0.
+		opCount := 1
+		if txMeta.V == 4 {
+			opCount = len(txMeta.MustV4().Operations)
+		}
+
+		for op := 0; op < opCount; op++ {
+			contractEvents, err := txMeta.GetContractEventsForOperation(uint32(op))
+			if err != nil {
+				return nil, fmt.Errorf("error reading events of transaction %x: %w", hash, err)
+			}
+
+			for eventIdx, contractEvent := range contractEvents {
+				event := xdr.DiagnosticEvent{InSuccessfulContractCall: true, Event: contractEvent}
+				if !request.Matches(event) {
+					continue
+				}
+
+				cursor := protocol.Cursor{
+					Ledger: sequence,
+					Tx:     uint32(idx + 1),
+					Op:     uint32(op),
+					Event:  uint32(eventIdx),
+				}
+				info, err := eventInfo(contractEvent, cursor, request.Format)
+				if err != nil {
+					return nil, err
+				}
+				info.LedgerClosedAt = closedAt
+				info.TransactionHash = hash.HexString()
+				events = append(events, info)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// LocalEvents applies request to the events of ledgers, a source such as
+// RPCLedgerCloseMetas or FileLedgerCloseMetas. It honours the request's ledger
+// range, cursor and limit like getEvents, but is not bound by the RPC's event
+// retention or its filter limits.
+func LocalEvents(
+	ledgers iter.Seq2[xdr.LedgerCloseMeta, error],
+	request protocol.GetEventsRequest,
+) iter.Seq2[protocol.EventInfo, error] {
+	return func(yield func(protocol.EventInfo, error) bool) {
+		var after *protocol.Cursor
+		var limit uint
+		if request.Pagination != nil {
+			after = request.Pagination.Cursor
+			limit = request.Pagination.Limit
+		}
+
+		var delivered uint
+		for meta, err := range ledgers {
+			if err != nil {
+				yield(protocol.EventInfo{}, err)
+				return
+			}
+
+			sequence := meta.LedgerSequence()
+			if sequence < request.StartLedger || (after != nil && sequence < after.Ledger) {
+				continue
+			}
+			if request.EndLedger != 0 && sequence >= request.EndLedger {
+				return
+			}
+
+			events, err := LedgerEvents(meta, request)
+			if err != nil {
+				yield(protocol.EventInfo{}, fmt.Errorf("error reading events of ledger %d: %w", sequence, err))
+				return
+			}
+
+			for _, event := range events {
+				if after != nil {
+					cursor, err := protocol.ParseCursor(event.ID)
+					if err != nil {
+						yield(protocol.EventInfo{}, err)
+						return
+					}
+					if cursor.Cmp(*after) <= 0 {
+						continue
+					}
+				}
+
+				if !yield(event, nil) {
+					return
+				}
+				delivered++
+				if limit != 0 && delivered >= limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RPCLedgerCloseMetas reads ledger close meta from getLedgers, which serves a
+// much longer history than getEvents.
+func RPCLedgerCloseMetas(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	opts soroban.IterOptions,
+) iter.Seq2[xdr.LedgerCloseMeta, error] {
+	return func(yield func(xdr.LedgerCloseMeta, error) bool) {
+		for ledger, err := range rpc.DecodedLedgers(ctx, opts) {
+			if !yield(ledger.Meta, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// LocalEventsCall runs request against the ledgers getLedgers serves instead
+// of against getEvents.
+func LocalEventsCall(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	request protocol.GetEventsRequest,
+) iter.Seq2[protocol.EventInfo, error] {
+	opts := soroban.IterOptions{StartLedger: request.StartLedger}
+	if request.Pagination != nil && request.Pagination.Cursor != nil {
+		opts.StartLedger = request.Pagination.Cursor.Ledger
+	}
+	if request.EndLedger != 0 {
+		opts.EndLedger = request.EndLedger - 1
+	}
+
+	return LocalEvents(RPCLedgerCloseMetas(ctx, rpc, opts), request)
+}
+
+// FileLedgerCloseMetas reads ledger close meta from files in the order given.
+// Each file holds an XDR LedgerCloseMetaBatch, optionally zstd compressed, as
+// exported by galexie.
+func FileLedgerCloseMetas(paths ...string) iter.Seq2[xdr.LedgerCloseMeta, error] {
+	return func(yield func(xdr.LedgerCloseMeta, error) bool) {
+		for _, path := range paths {
+			batch, err := readLedgerCloseMetaBatch(path)
+			if err != nil {
+				yield(xdr.LedgerCloseMeta{}, fmt.Errorf("error reading %s: %w", path, err))
+				return
+			}
+
+			for _, meta := range batch.LedgerCloseMetas {
+				if !yield(meta, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func readLedgerCloseMetaBatch(path string) (xdr.LedgerCloseMetaBatch, error) {
+	var batch xdr.LedgerCloseMetaBatch
+
+	file, err := os.Open(path)
+	if err != nil {
+		return batch, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var source io.Reader = reader
+	if magic, err := reader.Peek(len(zstdMagic)); err == nil && bytes.Equal(magic, zstdMagic) {
+		decoder, err := zstd.NewReader(reader)
+		if err != nil {
+			return batch, err
+		}
+		defer decoder.Close()
+		source = decoder
+	}
+
+	data, err := io.ReadAll(source)
+	if err != nil {
+		return batch, err
+	}
+	if err := batch.UnmarshalBinary(data); err != nil {
+		return batch, err
+	}
+	return batch, nil
+}
+
+// eventInfo renders an event the way getEvents does, leaving the close time
+// and transaction hash to the caller.
+func eventInfo(event xdr.ContractEvent, cursor protocol.Cursor, format string) (protocol.EventInfo, error) {
+	info := protocol.EventInfo{
+		EventType:                protocol.GetEventTypeFromEventTypeXDR()[event.Type],
+		Ledger:                   int32(cursor.Ledger),
+		ID:                       cursor.String(),
+		OpIndex:                  cursor.Op,
+		TxIndex:                  cursor.Tx,
+		InSuccessfulContractCall: true,
+	}
+	if event.ContractId != nil {
+		info.ContractID = strkey.MustEncode(strkey.VersionByteContract, event.ContractId[:])
+	}
+
+	v0, ok := event.Body.GetV0()
+	if !ok {
+		return protocol.EventInfo{}, fmt.Errorf("unsupported body in event %s", info.ID)
+	}
+
+	if format == protocol.FormatJSON {
+		info.TopicJSON = make([]json.RawMessage, len(v0.Topics))
+		for idx, topic := range v0.Topics {
+			encoded, err := xdrjson.MarshalScVal(topic)
+			if err != nil {
+				return protocol.EventInfo{}, fmt.Errorf("error encoding topic %d of event %s: %w", idx, info.ID, err)
+			}
+			info.TopicJSON[idx] = encoded
+		}
+		encoded, err := xdrjson.MarshalScVal(v0.Data)
+		if err != nil {
+			return protocol.EventInfo{}, fmt.Errorf("error encoding value of event %s: %w", info.ID, err)
+		}
+		info.ValueJSON = encoded
+		return info, nil
+	}
+
+	info.TopicXDR = make([]string, len(v0.Topics))
+	for idx, topic := range v0.Topics {
+		encoded, err := xdr.MarshalBase64(topic)
+		if err != nil {
+			return protocol.EventInfo{}, fmt.Errorf("error encoding topic %d of event %s: %w", idx, info.ID, err)
+		}
+		info.TopicXDR[idx] = encoded
+	}
+	encoded, err := xdr.MarshalBase64(v0.Data)
+	if err != nil {
+		return protocol.EventInfo{}, fmt.Errorf("error encoding value of event %s: %w", info.ID, err)
+	}
+	info.ValueXDR = encoded
+	return info, nil
+}
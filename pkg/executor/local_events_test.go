@@ -0,0 +1,112 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func testLedgerCloseMeta(sequence uint32, contracts []xdr.ContractId) xdr.LedgerCloseMeta {
+	event := func(contract xdr.ContractId, name xdr.ScSymbol) xdr.ContractEvent {
+		return xdr.ContractEvent{
+			ContractId: &contract,
+			Type:       xdr.ContractEventTypeContract,
+			Body: xdr.ContractEventBody{
+				V: 0,
+				V0: &xdr.ContractEventV0{
+					Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvSymbol, Sym: &name}},
+					Data:   xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+				},
+			},
+		}
+	}
+	result := func(code xdr.TransactionResultCode, hash byte) xdr.TransactionResultPair {
+		return xdr.TransactionResultPair{
+			TransactionHash: xdr.Hash{hash},
+			Result: xdr.TransactionResult{
+				Result: xdr.TransactionResultResult{Code: code, Results: &[]xdr.OperationResult{}},
+			},
+		}
+	}
+
+	failed := xdr.TransactionResultMeta{
+		Result:            result(xdr.TransactionResultCodeTxFailed, 1),
+		TxApplyProcessing: xdr.TransactionMeta{V: 4, V4: &xdr.TransactionMetaV4{}},
+	}
+	succeeded := xdr.TransactionResultMeta{
+		Result: result(xdr.TransactionResultCodeTxSuccess, 2),
+		TxApplyProcessing: xdr.TransactionMeta{V: 4, V4: &xdr.TransactionMetaV4{
+			Operations: []xdr.OperationMetaV2{
+				{Events: []xdr.ContractEvent{event(contracts[0], "first"), event(contracts[1], "other")}},
+				{Events: []xdr.ContractEvent{event(contracts[0], "second")}},
+			},
+		}},
+	}
+
+	meta := xdr.LedgerCloseMeta{
+		V: 1,
+		V1: &xdr.LedgerCloseMetaV1{
+			TxSet:        xdr.GeneralizedTransactionSet{V: 1, V1TxSet: &xdr.TransactionSetV1{}},
+			TxProcessing: []xdr.TransactionResultMeta{failed, succeeded},
+		},
+	}
+	meta.V1.LedgerHeader.Header.LedgerSeq = xdr.Uint32(sequence)
+	meta.V1.LedgerHeader.Header.ScpValue.CloseTime = 1700000000
+	return meta
+}
+
+func TestLocalEventsFromFiles(t *testing.T) {
+	contracts := []xdr.ContractId{{31: 0}, {31: 1}}
+	batch := xdr.LedgerCloseMetaBatch{
+		StartSequence: 10,
+		EndSequence:   11,
+		LedgerCloseMetas: []xdr.LedgerCloseMeta{
+			testLedgerCloseMeta(10, contracts),
+			testLedgerCloseMeta(11, contracts),
+		},
+	}
+	raw, err := batch.MarshalBinary()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "ledgers.xdr")
+	require.NoError(t, os.WriteFile(plain, raw, 0o600))
+
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := filepath.Join(dir, "ledgers.xdr.zst")
+	require.NoError(t, os.WriteFile(compressed, encoder.EncodeAll(raw, nil), 0o600))
+
+	contractID := testContractIDs(2)[0]
+	filters, err := NewEventFilter().Contracts(contractID).Build()
+	require.NoError(t, err)
+	after := protocol.Cursor{Ledger: 10, Tx: 2, Op: 0, Event: 0}
+
+	for _, path := range []string{plain, compressed} {
+		var ids []protocol.Cursor
+		for info, err := range LocalEvents(FileLedgerCloseMetas(path), protocol.GetEventsRequest{
+			Filters:    filters,
+			Pagination: &protocol.PaginationOptions{Cursor: &after, Limit: 2},
+		}) {
+			require.NoError(t, err)
+			assert.Equal(t, contractID, info.ContractID)
+			assert.Equal(t, "2023-11-14T22:13:20Z", info.LedgerClosedAt)
+
+			record, err := decodeEventRecord(info)
+			require.NoError(t, err)
+			ids = append(ids, record.Cursor)
+		}
+
+		assert.Equal(t, []protocol.Cursor{
+			{Ledger: 10, Tx: 2, Op: 1, Event: 0},
+			{Ledger: 11, Tx: 2, Op: 0, Event: 0},
+		}, ids)
+	}
+}
@@ -0,0 +1,156 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/stellar/go/xdr"
+
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// rpcRequest is a JSON-RPC request received by a mockRPC.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// decode unmarshals the request parameters into params.
+func (r rpcRequest) decode(params any) error {
+	return json.Unmarshal(r.Params, params)
+}
+
+// rpcResult answers a request with a JSON result. An error fails the call
+// with a JSON-RPC error carrying its message.
+type rpcResult func(req rpcRequest) (string, error)
+
+// staticResult answers every request with result.
+func staticResult(result string) rpcResult {
+	return func(rpcRequest) (string, error) {
+		return result, nil
+	}
+}
+
+// sequentialResults answers successive requests with results in order,
+// repeating the last one once they run out.
+func sequentialResults(results ...string) rpcResult {
+	var calls atomic.Int32
+	return func(rpcRequest) (string, error) {
+		return results[min(int(calls.Add(1))-1, len(results)-1)], nil
+	}
+}
+
+// pendingTransactions accepts every transaction sent, naming them tx1, tx2
+// and so on.
+func pendingTransactions() rpcResult {
+	var sent atomic.Int32
+	return func(rpcRequest) (string, error) {
+		return fmt.Sprintf(`{"status":"PENDING","hash":"tx%d"}`, sent.Add(1)), nil
+	}
+}
+
+// mockRPC is a JSON-RPC server that answers each method with its rpcResult
+// and records the methods called and the transactions sent.
+//
+// Results run off the test goroutine, so they must not stop the test. They
+// return errors instead, which fail the client call and with it the test.
+type mockRPC struct {
+	results map[string]rpcResult
+
+	mx           sync.Mutex
+	methods      []string
+	transactions []xdr.TransactionEnvelope
+}
+
+func newMockRPC(t *testing.T, results map[string]rpcResult, opts ...soroban.ClientOption) (*mockRPC, *soroban.RpcClient) {
+	server := &mockRPC{results: results}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	rpc := soroban.NewClient(httpServer.URL, nil, opts...)
+	t.Cleanup(func() { rpc.Close() })
+	return server, rpc
+}
+
+func (s *mockRPC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, ok := s.results[req.Method]
+	if !ok {
+		writeRPCError(w, req.ID, jrpc2.MethodNotFound, "unexpected method "+req.Method)
+		return
+	}
+	if err := s.record(req); err != nil {
+		writeRPCError(w, req.ID, jrpc2.InvalidParams, err.Error())
+		return
+	}
+
+	body, err := result(req)
+	if err != nil {
+		writeRPCError(w, req.ID, jrpc2.InvalidParams, err.Error())
+		return
+	}
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, body)
+}
+
+func (s *mockRPC) record(req rpcRequest) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.methods = append(s.methods, req.Method)
+	if req.Method != protocol.SendTransactionMethodName {
+		return nil
+	}
+
+	var params protocol.SendTransactionRequest
+	if err := req.decode(&params); err != nil {
+		return err
+	}
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(params.Transaction, &envelope); err != nil {
+		return err
+	}
+	s.transactions = append(s.transactions, envelope)
+	return nil
+}
+
+// calls returns the methods called so far, in order.
+func (s *mockRPC) calls() []string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return append([]string(nil), s.methods...)
+}
+
+// sent returns the transactions sent so far, in order.
+func (s *mockRPC) sent() []xdr.TransactionEnvelope {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return append([]xdr.TransactionEnvelope(nil), s.transactions...)
+}
+
+// reset forgets the methods called and the transactions sent.
+func (s *mockRPC) reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.methods = nil
+	s.transactions = nil
+}
+
+func writeRPCError(w http.ResponseWriter, id json.RawMessage, code jrpc2.Code, message string) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":%s}}`, id, code, body)
+}
@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// RestoreFootprint submits a RestoreFootprint transaction built from a
+// simulation's restore preamble and waits for it to land. The preamble's
+// transaction data already carries the footprint of archived entries, so no
+// further simulation is needed.
+func RestoreFootprint(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	sourceAccount txnbuild.Account,
+	preamble protocol.RestorePreamble,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	restoreTx, err := buildSorobanTx(sourceAccount, &txnbuild.RestoreFootprint{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreTx, err = AssembleTransaction(
+		restoreTx,
+		protocol.SimulateTransactionResponse{
+			TransactionDataXDR:  preamble.TransactionDataXDR,
+			TransactionDataJSON: preamble.TransactionDataJSON,
+			MinResourceFee:      preamble.MinResourceFee,
+		},
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return SubmitAndWait(ctx, rpc, restoreTx, networkPassphrase, signingKeypairs, opts)
+}
+
+// prepareContractCallWithRestore prepares the call and, when opts.AutoRestore
+// is set and simulation asks for archived entries to be restored, restores
+// them and prepares the call again.
+func prepareContractCallWithRestore(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*txnbuild.Transaction, error) {
+	transactionXdr, err := PrepareContractCall(ctx, rpc, contractAddress, sourceAccount, args, functionName, opts)
+
+	var restoreErr *RestoreRequiredError
+	if !opts.AutoRestore || !errors.As(err, &restoreErr) {
+		return transactionXdr, err
+	}
+
+	_, err = RestoreFootprint(ctx, rpc, sourceAccount, restoreErr.Preamble, networkPassphrase, signingKeypairs, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring archived footprint: %w", err)
+	}
+
+	return PrepareContractCall(ctx, rpc, contractAddress, sourceAccount, args, functionName, opts)
+}
@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestInvokeContractCallRestoresFootprint(t *testing.T) {
+	contractId := xdr.ContractId{1}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractId}
+	archivedKey := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   contract,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+
+	preambleData := xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{
+			Footprint:     xdr.LedgerFootprint{ReadWrite: []xdr.LedgerKey{archivedKey}},
+			DiskReadBytes: 100,
+		},
+	}
+	callData := xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{
+			Footprint:    xdr.LedgerFootprint{ReadOnly: []xdr.LedgerKey{archivedKey}},
+			Instructions: 2000,
+		},
+	}
+	returnValue := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: new(xdr.Uint32)}
+	*returnValue.U32 = 9
+	meta := xdr.TransactionMeta{
+		V: 4,
+		V4: &xdr.TransactionMetaV4{
+			Operations:  []xdr.OperationMetaV2{{}},
+			SorobanMeta: &xdr.SorobanTransactionMetaV2{ReturnValue: &returnValue},
+		},
+	}
+
+	preambleDataXdr, err := xdr.MarshalBase64(preambleData)
+	require.NoError(t, err)
+	callDataXdr, err := xdr.MarshalBase64(callData)
+	require.NoError(t, err)
+	returnMetaXdr, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	// The first simulation asks for a restore, later ones succeed.
+	server, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.SimulateTransactionMethodName: sequentialResults(
+			fmt.Sprintf(
+				`{"transactionData":%q,"minResourceFee":"10","restorePreamble":{"transactionData":%q,"minResourceFee":"500"},"latestLedger":5}`,
+				callDataXdr, preambleDataXdr,
+			),
+			fmt.Sprintf(
+				`{"transactionData":%q,"minResourceFee":"1000","results":[{"xdr":"AAAAAQ=="}],"latestLedger":6}`,
+				callDataXdr,
+			),
+		),
+		protocol.SendTransactionMethodName: pendingTransactions(),
+		protocol.GetTransactionMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.GetTransactionRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			meta := ""
+			if params.Hash == "tx2" {
+				meta = fmt.Sprintf(`,"resultMetaXdr":%q`, returnMetaXdr)
+			}
+			return fmt.Sprintf(`{"status":"SUCCESS","ledger":7%s}`, meta), nil
+		},
+	})
+
+	signer := keypair.MustRandom()
+	source := txnbuild.NewSimpleAccount(signer.Address(), 10)
+	opts := SubmitOptions{
+		BaseFee:           100,
+		ResourceFeeMargin: 0.1,
+		PollInterval:      time.Millisecond,
+		AutoRestore:       true,
+	}
+
+	result, err := InvokeContractCall(
+		context.Background(), rpc, contract, &source, nil, "balance",
+		network.TestNetworkPassphrase, []*keypair.Full{signer}, opts,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, result.ReturnValue)
+	assert.True(t, result.ReturnValue.Equals(returnValue))
+	assert.Equal(t, "tx2", result.Hash)
+
+	assert.Equal(t, []string{
+		protocol.SimulateTransactionMethodName,
+		protocol.SendTransactionMethodName,
+		protocol.GetTransactionMethodName,
+		protocol.SimulateTransactionMethodName,
+		protocol.SendTransactionMethodName,
+		protocol.GetTransactionMethodName,
+	}, server.calls())
+	envelopes := server.sent()
+	require.Len(t, envelopes, 2)
+
+	restoreTx := envelopes[0].V1.Tx
+	assert.Equal(t, xdr.SequenceNumber(11), restoreTx.SeqNum)
+	require.Len(t, restoreTx.Operations, 1)
+	assert.Equal(t, xdr.OperationTypeRestoreFootprint, restoreTx.Operations[0].Body.Type)
+	require.NotNil(t, restoreTx.Ext.SorobanData)
+	assert.Equal(t, xdr.Int64(550), restoreTx.Ext.SorobanData.ResourceFee)
+	assert.Equal(t, preambleData.Resources, restoreTx.Ext.SorobanData.Resources)
+	assert.Equal(t, xdr.Uint32(100+550), restoreTx.Fee)
+
+	callTx := envelopes[1].V1.Tx
+	assert.Equal(t, xdr.SequenceNumber(12), callTx.SeqNum)
+	require.Len(t, callTx.Operations, 1)
+	assert.Equal(t, xdr.OperationTypeInvokeHostFunction, callTx.Operations[0].Body.Type)
+	require.NotNil(t, callTx.Ext.SorobanData)
+	assert.Equal(t, xdr.Int64(1100), callTx.Ext.SorobanData.ResourceFee)
+	assert.Equal(t, callData.Resources, callTx.Ext.SorobanData.Resources)
+}
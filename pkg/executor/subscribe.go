@@ -0,0 +1,239 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/checkpoint"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+const (
+	// DefaultEventPollInterval is roughly the network's ledger close time.
+	DefaultEventPollInterval = 5 * time.Second
+	DefaultEventPageLimit    = 100
+)
+
+// RetentionWindowError is returned when a subscription would start, or has
+// fallen behind, before the oldest ledger the RPC still retains events for.
+// Events in the gap are lost and cannot be delivered.
+type RetentionWindowError struct {
+	Ledger       uint32
+	OldestLedger uint32
+}
+
+func (e *RetentionWindowError) Error() string {
+	return fmt.Sprintf(
+		"ledger %d is outside the RPC retention window, which starts at ledger %d",
+		e.Ledger,
+		e.OldestLedger,
+	)
+}
+
+// SubscribeOptions configures where a subscription starts and how it polls.
+type SubscribeOptions struct {
+	// Cursor resumes a subscription after a previously delivered event or
+	// page. It takes precedence over StartLedger.
+	Cursor *protocol.Cursor
+	// StartLedger is the first ledger to deliver events from. When neither it
+	// nor Cursor is set, the subscription starts at the latest ledger.
+	StartLedger uint32
+	Filters     []protocol.EventFilter
+	// Limit is the page size requested from getEvents.
+	Limit        uint
+	PollInterval time.Duration
+	// OnCursor, if set, is called with the stream position after each page
+	// has been delivered, including pages without events. Persisting it lets
+	// a subscription resume where it left off.
+	OnCursor func(ctx context.Context, cursor protocol.Cursor) error
+}
+
+// EventHandler handles an event delivered by Subscribe. Returning an error
+// stops the subscription without advancing past the event.
+type EventHandler func(ctx context.Context, event EventRecord) error
+
+// Subscribe follows the getEvents cursor from opts' starting point and hands
+// every matching event to handler in order, polling for new ledgers every
+// opts.PollInterval once it has caught up. Delivery is at least once: the
+// cursor only moves past an event after handler returns, so a subscription
+// resumed from a saved cursor may repeat events the handler already saw.
+//
+// Subscribe blocks until ctx ends, handler or opts.OnCursor fail, or the
+// stream falls outside the RPC retention window, in which case a
+// *RetentionWindowError is returned.
+func Subscribe(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	opts SubscribeOptions,
+	handler EventHandler,
+) error {
+	limit := opts.Limit
+	if limit == 0 {
+		limit = DefaultEventPageLimit
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultEventPollInterval
+	}
+
+	cursor, startLedger, err := subscriptionStart(ctx, rpc, opts)
+	if err != nil {
+		return err
+	}
+
+	for {
+		request := protocol.GetEventsRequest{
+			Filters:    opts.Filters,
+			Pagination: &protocol.PaginationOptions{Limit: limit},
+		}
+		if cursor != nil {
+			request.Pagination.Cursor = cursor
+		} else {
+			request.StartLedger = startLedger
+		}
+
+		resp, err := getEvents(ctx, rpc, request)
+		if err != nil {
+			return err
+		}
+
+		position := startLedger
+		if cursor != nil {
+			position = cursor.Ledger
+		}
+		if position < resp.OldestLedger {
+			return &RetentionWindowError{Ledger: position, OldestLedger: resp.OldestLedger}
+		}
+
+		for _, info := range resp.Events {
+			event, err := decodeEventRecord(info)
+			if err != nil {
+				return err
+			}
+
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+			cursor = &event.Cursor
+		}
+
+		if resp.Cursor != "" {
+			pageCursor, err := protocol.ParseCursor(resp.Cursor)
+			if err != nil {
+				return err
+			}
+			cursor = &pageCursor
+		}
+
+		if cursor != nil && opts.OnCursor != nil {
+			if err := opts.OnCursor(ctx, *cursor); err != nil {
+				return err
+			}
+		}
+
+		// A full page means there may be more events ready right away.
+		if uint(len(resp.Events)) >= limit {
+			continue
+		}
+		if err := soroban.SleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// SubscribeWithStore resumes the subscription from the cursor saved in store
+// under name, if any, and saves the stream position back to it after every
+// page. Without a saved cursor it starts from opts' starting point. An
+// opts.OnCursor callback still runs after each save.
+func SubscribeWithStore(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	store checkpoint.CursorStore,
+	name string,
+	opts SubscribeOptions,
+	handler EventHandler,
+) error {
+	saved, err := checkpoint.LoadEventCursor(ctx, store, name)
+	if err != nil {
+		return fmt.Errorf("error loading cursor %s: %w", name, err)
+	}
+	if saved != nil {
+		opts.Cursor = saved
+	}
+
+	onCursor := opts.OnCursor
+	opts.OnCursor = func(ctx context.Context, cursor protocol.Cursor) error {
+		if err := checkpoint.SaveEventCursor(ctx, store, name, cursor); err != nil {
+			return err
+		}
+		if onCursor != nil {
+			return onCursor(ctx, cursor)
+		}
+		return nil
+	}
+
+	return Subscribe(ctx, rpc, opts, handler)
+}
+
+// SubscribeChannel runs Subscribe in a goroutine and delivers events on the
+// returned channel. An event counts as delivered once it is received from the
+// channel. The error channel receives the error Subscribe stopped with, after
+// which both channels are closed.
+func SubscribeChannel(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	opts SubscribeOptions,
+) (<-chan EventRecord, <-chan error) {
+	events := make(chan EventRecord)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		errs <- Subscribe(ctx, rpc, opts, func(ctx context.Context, event EventRecord) error {
+			select {
+			case events <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return events, errs
+}
+
+// subscriptionStart resolves the subscription's starting point and checks it
+// against the RPC's retention window before the first request, since
+// getEvents rejects out of range start ledgers without saying where the
+// window begins.
+func subscriptionStart(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	opts SubscribeOptions,
+) (*protocol.Cursor, uint32, error) {
+	health, err := rpc.GetHealth(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.Cursor != nil {
+		cursor := *opts.Cursor
+		if cursor.Ledger < health.OldestLedger {
+			return nil, 0, &RetentionWindowError{Ledger: cursor.Ledger, OldestLedger: health.OldestLedger}
+		}
+		return &cursor, 0, nil
+	}
+
+	startLedger := opts.StartLedger
+	if startLedger == 0 {
+		startLedger = health.LatestLedger
+	}
+	if startLedger < health.OldestLedger {
+		return nil, 0, &RetentionWindowError{Ledger: startLedger, OldestLedger: health.OldestLedger}
+	}
+	return nil, startLedger, nil
+}
@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+type getEventsParams struct {
+	StartLedger uint32            `json:"startLedger"`
+	Filters     []json.RawMessage `json:"filters"`
+	Pagination  *struct {
+		Cursor string `json:"cursor"`
+	} `json:"pagination"`
+}
+
+// newEventsRPC serves getHealth with health and getEvents from pages.
+func newEventsRPC(
+	t *testing.T,
+	health string,
+	pages func(params getEventsParams) string,
+) *soroban.RpcClient {
+	_, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.GetHealthMethodName: staticResult(health),
+		protocol.GetEventsMethodName: func(req rpcRequest) (string, error) {
+			var params getEventsParams
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			return pages(params), nil
+		},
+	})
+	return rpc
+}
+
+// testEventInfo is called from server handlers, so it must not stop the test.
+func testEventInfo(t *testing.T, cursor protocol.Cursor) string {
+	value, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvVoid})
+	assert.NoError(t, err)
+	info, err := json.Marshal(protocol.EventInfo{
+		EventType: protocol.EventTypeContract,
+		Ledger:    int32(cursor.Ledger),
+		ID:        cursor.String(),
+		ValueXDR:  value,
+	})
+	assert.NoError(t, err)
+	return string(info)
+}
+
+func TestSubscribeFollowsCursors(t *testing.T) {
+	first := protocol.Cursor{Ledger: 10, Tx: 1}
+	second := protocol.Cursor{Ledger: 11, Tx: 2}
+	endOfWindow := protocol.Cursor{Ledger: 12}
+
+	var mx sync.Mutex
+	var requested []getEventsParams
+	rpc := newEventsRPC(
+		t,
+		`{"status":"healthy","latestLedger":12,"oldestLedger":5}`,
+		func(params getEventsParams) string {
+			mx.Lock()
+			requested = append(requested, params)
+			mx.Unlock()
+
+			if params.Pagination.Cursor == "" {
+				return fmt.Sprintf(
+					`{"events":[%s,%s],"cursor":%q,"latestLedger":12,"oldestLedger":5}`,
+					testEventInfo(t, first), testEventInfo(t, second), second.String(),
+				)
+			}
+			return fmt.Sprintf(`{"events":[],"cursor":%q,"latestLedger":12,"oldestLedger":5}`, endOfWindow.String())
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var delivered []protocol.Cursor
+	var saved []protocol.Cursor
+	opts := SubscribeOptions{
+		StartLedger:  10,
+		Limit:        2,
+		PollInterval: time.Millisecond,
+		OnCursor: func(ctx context.Context, cursor protocol.Cursor) error {
+			saved = append(saved, cursor)
+			if len(saved) == 2 {
+				cancel()
+			}
+			return nil
+		},
+	}
+	err := Subscribe(ctx, rpc, opts, func(ctx context.Context, event EventRecord) error {
+		delivered = append(delivered, event.Cursor)
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, []protocol.Cursor{first, second}, delivered)
+	assert.Equal(t, []protocol.Cursor{second, endOfWindow}, saved)
+
+	mx.Lock()
+	defer mx.Unlock()
+	require.GreaterOrEqual(t, len(requested), 2)
+	assert.Equal(t, uint32(10), requested[0].StartLedger)
+	assert.Equal(t, second.String(), requested[1].Pagination.Cursor)
+}
+
+func TestSubscribeOutsideRetentionWindow(t *testing.T) {
+	rpc := newEventsRPC(
+		t,
+		`{"status":"healthy","latestLedger":100,"oldestLedger":50}`,
+		func(getEventsParams) string {
+			t.Error("getEvents should not be called")
+			return ""
+		},
+	)
+
+	err := Subscribe(
+		context.Background(),
+		rpc,
+		SubscribeOptions{Cursor: &protocol.Cursor{Ledger: 20}},
+		func(context.Context, EventRecord) error { return nil },
+	)
+
+	var retentionErr *RetentionWindowError
+	require.True(t, errors.As(err, &retentionErr))
+	assert.Equal(t, uint32(20), retentionErr.Ledger)
+	assert.Equal(t, uint32(50), retentionErr.OldestLedger)
+}
@@ -0,0 +1,201 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+)
+
+// MissingEntriesError is returned when ledger keys that must exist have no
+// entry.
+type MissingEntriesError struct {
+	Keys []xdr.LedgerKey
+}
+
+func (e *MissingEntriesError) Error() string {
+	return fmt.Sprintf("%d ledger entries not found", len(e.Keys))
+}
+
+// ArchivedEntriesError is returned when ledger entries have been archived
+// and must be restored before they can be used.
+type ArchivedEntriesError struct {
+	Keys []xdr.LedgerKey
+}
+
+func (e *ArchivedEntriesError) Error() string {
+	return fmt.Sprintf("%d ledger entries are archived and must be restored first", len(e.Keys))
+}
+
+// ExtendFootprintTTL extends the TTL of ledgerKeys so that they stay live
+// until at least liveUntilLedger. Keys that are already live until then are
+// skipped; if none are left, it returns a nil result without submitting
+// anything. Otherwise an ExtendFootprintTTL transaction is simulated,
+// assembled, submitted and waited on.
+//
+// Keys without an entry fail with a MissingEntriesError. Archived persistent
+// entries are restored first when opts.AutoRestore is set, and otherwise fail
+// with an ArchivedEntriesError; archived temporary entries are gone and
+// always fail.
+func ExtendFootprintTTL(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	sourceAccount txnbuild.Account,
+	ledgerKeys []xdr.LedgerKey,
+	liveUntilLedger uint32,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	result, err := LedgerEntriesCall(ctx, rpc, ledgerKeys, DefaultLedgerEntriesOptions())
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Missing) > 0 {
+		return nil, &MissingEntriesError{Keys: result.Missing}
+	}
+	latestLedger := result.LatestLedger
+
+	if liveUntilLedger <= latestLedger {
+		return nil, fmt.Errorf(
+			"target live until ledger %d must be after the latest ledger %d",
+			liveUntilLedger,
+			latestLedger,
+		)
+	}
+
+	var footprint, archived []xdr.LedgerKey
+	for _, entry := range result.Found {
+		ttl, ok := entry.TTL(latestLedger)
+		if !ok {
+			return nil, fmt.Errorf("ledger entry of type %s has no TTL to extend", entry.Key.Type)
+		}
+		if ttl == 0 {
+			archived = append(archived, entry.Key)
+		}
+		if *entry.LiveUntilLedgerSeq < liveUntilLedger {
+			footprint = append(footprint, entry.Key)
+		}
+	}
+
+	if len(archived) > 0 {
+		if !opts.AutoRestore || slices.ContainsFunc(archived, isTemporary) {
+			return nil, &ArchivedEntriesError{Keys: archived}
+		}
+		_, err := restoreEntries(ctx, rpc, sourceAccount, archived, networkPassphrase, signingKeypairs, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error restoring archived entries: %w", err)
+		}
+	}
+
+	if len(footprint) == 0 {
+		return nil, nil
+	}
+
+	// ExtendTo is relative: the entries stay live for at least that many
+	// ledgers after the ledger the transaction is applied in.
+	extendTo := liveUntilLedger - latestLedger
+
+	transactionXdr, err := buildExtendFootprintTTLTx(sourceAccount, footprint, extendTo, false)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation, err := simulateTransaction(ctx, rpc, transactionXdr)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionXdr, err = buildExtendFootprintTTLTx(sourceAccount, footprint, extendTo, true)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionXdr, err = AssembleTransaction(transactionXdr, simulation, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return SubmitAndWait(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs, opts)
+}
+
+// restoreEntries simulates, submits and waits on a RestoreFootprint
+// transaction for archived persistent entries.
+func restoreEntries(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	sourceAccount txnbuild.Account,
+	archived []xdr.LedgerKey,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	buildRestoreTx := func(incrementSequence bool) (*txnbuild.Transaction, error) {
+		restoreOp := &txnbuild.RestoreFootprint{
+			Ext: xdr.TransactionExt{
+				V: 1,
+				SorobanData: &xdr.SorobanTransactionData{
+					Resources: xdr.SorobanResources{
+						Footprint: xdr.LedgerFootprint{
+							ReadWrite: archived,
+						},
+					},
+				},
+			},
+		}
+		return buildSorobanTx(sourceAccount, restoreOp, incrementSequence)
+	}
+
+	transactionXdr, err := buildRestoreTx(false)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation, err := simulateTransaction(ctx, rpc, transactionXdr)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionXdr, err = buildRestoreTx(true)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionXdr, err = AssembleTransaction(transactionXdr, simulation, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return SubmitAndWait(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs, opts)
+}
+
+func isTemporary(key xdr.LedgerKey) bool {
+	return key.ContractData != nil && key.ContractData.Durability == xdr.ContractDataDurabilityTemporary
+}
+
+func buildExtendFootprintTTLTx(
+	sourceAccount txnbuild.Account,
+	footprint []xdr.LedgerKey,
+	extendTo uint32,
+	incrementSequence bool,
+) (*txnbuild.Transaction, error) {
+	extendOp := &txnbuild.ExtendFootprintTtl{
+		ExtendTo: extendTo,
+		Ext: xdr.TransactionExt{
+			V: 1,
+			SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{
+					Footprint: xdr.LedgerFootprint{
+						ReadOnly: footprint,
+					},
+				},
+			},
+		},
+	}
+
+	return buildSorobanTx(sourceAccount, extendOp, incrementSequence)
+}
@@ -0,0 +1,202 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+const ttlTestLatestLedger = 1000
+
+func testContractDataKey(sym string, durability xdr.ContractDataDurability) xdr.LedgerKey {
+	contractId := xdr.ContractId{7}
+	symbol := xdr.ScSymbol(sym)
+	return xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractId},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &symbol},
+			Durability: durability,
+		},
+	}
+}
+
+// newTTLServer serves contract data entries with the given live until
+// ledgers and accepts every transaction sent to it.
+func newTTLServer(t *testing.T, liveUntil map[*xdr.LedgerKey]uint32) (*mockRPC, *soroban.RpcClient) {
+	encodedLiveUntil := map[string]uint32{}
+	for key, ledger := range liveUntil {
+		encoded, err := key.MarshalBinaryBase64()
+		require.NoError(t, err)
+		encodedLiveUntil[encoded] = ledger
+	}
+
+	return newMockRPC(t, map[string]rpcResult{
+		protocol.GetLedgerEntriesMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.GetLedgerEntriesRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			entries := []map[string]any{}
+			for _, key := range params.Keys {
+				liveUntil, ok := encodedLiveUntil[key]
+				if !ok {
+					continue
+				}
+				var ledgerKey xdr.LedgerKey
+				if err := xdr.SafeUnmarshalBase64(key, &ledgerKey); err != nil {
+					return "", err
+				}
+				dataXdr, err := xdr.MarshalBase64(xdr.LedgerEntryData{
+					Type: xdr.LedgerEntryTypeContractData,
+					ContractData: &xdr.ContractDataEntry{
+						Contract:   ledgerKey.ContractData.Contract,
+						Key:        ledgerKey.ContractData.Key,
+						Durability: ledgerKey.ContractData.Durability,
+						Val:        xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+					},
+				})
+				if err != nil {
+					return "", err
+				}
+				entries = append(entries, map[string]any{
+					"key":                   key,
+					"xdr":                   dataXdr,
+					"lastModifiedLedgerSeq": 1,
+					"liveUntilLedgerSeq":    liveUntil,
+				})
+			}
+			result, err := json.Marshal(map[string]any{"entries": entries, "latestLedger": ttlTestLatestLedger})
+			return string(result), err
+		},
+		// Echo the footprint back, as simulation does for these operations.
+		protocol.SimulateTransactionMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.SimulateTransactionRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			var envelope xdr.TransactionEnvelope
+			if err := xdr.SafeUnmarshalBase64(params.Transaction, &envelope); err != nil {
+				return "", err
+			}
+			dataXdr, err := xdr.MarshalBase64(envelope.V1.Tx.Ext.SorobanData)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf(`{"transactionData":%q,"minResourceFee":"100","latestLedger":%d}`, dataXdr, ttlTestLatestLedger), nil
+		},
+		protocol.SendTransactionMethodName: pendingTransactions(),
+		protocol.GetTransactionMethodName:  staticResult(fmt.Sprintf(`{"status":"SUCCESS","ledger":%d}`, ttlTestLatestLedger+1)),
+	})
+}
+
+func extendTTL(rpc *soroban.RpcClient, keys []xdr.LedgerKey, autoRestore bool) (*TransactionResult, error) {
+	signer := keypair.MustRandom()
+	source := txnbuild.NewSimpleAccount(signer.Address(), 10)
+	opts := DefaultSubmitOptions()
+	opts.PollInterval = time.Millisecond
+	opts.AutoRestore = autoRestore
+	return ExtendFootprintTTL(
+		context.Background(), rpc, &source, keys, ttlTestLatestLedger+500,
+		network.TestNetworkPassphrase, []*keypair.Full{signer}, opts,
+	)
+}
+
+func TestExtendFootprintTTL(t *testing.T) {
+	short := testContractDataKey("short", xdr.ContractDataDurabilityPersistent)
+	long := testContractDataKey("long", xdr.ContractDataDurabilityPersistent)
+	server, rpc := newTTLServer(t, map[*xdr.LedgerKey]uint32{
+		&short: ttlTestLatestLedger + 10,
+		&long:  ttlTestLatestLedger + 1000,
+	})
+
+	result, err := extendTTL(rpc, []xdr.LedgerKey{short, long}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "tx1", result.Hash)
+
+	envelopes := server.sent()
+	require.Len(t, envelopes, 1)
+	op := envelopes[0].V1.Tx.Operations[0].Body
+	require.NotNil(t, op.ExtendFootprintTtlOp)
+	assert.Equal(t, xdr.Uint32(500), op.ExtendFootprintTtlOp.ExtendTo)
+	assert.Equal(t, []xdr.LedgerKey{short}, envelopes[0].V1.Tx.Ext.SorobanData.Resources.Footprint.ReadOnly)
+
+	// Nothing left to extend.
+	server.reset()
+	result, err = extendTTL(rpc, []xdr.LedgerKey{long}, false)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Empty(t, server.sent())
+}
+
+func TestExtendFootprintTTLMissingEntries(t *testing.T) {
+	live := testContractDataKey("live", xdr.ContractDataDurabilityPersistent)
+	missing := testContractDataKey("missing", xdr.ContractDataDurabilityPersistent)
+	server, rpc := newTTLServer(t, map[*xdr.LedgerKey]uint32{&live: ttlTestLatestLedger + 10})
+
+	_, err := extendTTL(rpc, []xdr.LedgerKey{live, missing}, true)
+	var missingErr *MissingEntriesError
+	require.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, []xdr.LedgerKey{missing}, missingErr.Keys)
+	assert.Empty(t, server.sent())
+}
+
+func TestExtendFootprintTTLArchivedEntries(t *testing.T) {
+	archived := testContractDataKey("archived", xdr.ContractDataDurabilityPersistent)
+	expired := testContractDataKey("expired", xdr.ContractDataDurabilityTemporary)
+	live := testContractDataKey("live", xdr.ContractDataDurabilityPersistent)
+	server, rpc := newTTLServer(t, map[*xdr.LedgerKey]uint32{
+		&archived: ttlTestLatestLedger - 5,
+		&expired:  ttlTestLatestLedger - 5,
+		&live:     ttlTestLatestLedger + 10,
+	})
+
+	_, err := extendTTL(rpc, []xdr.LedgerKey{archived, live}, false)
+	var archivedErr *ArchivedEntriesError
+	require.True(t, errors.As(err, &archivedErr))
+	assert.Equal(t, []xdr.LedgerKey{archived}, archivedErr.Keys)
+
+	// Temporary entries cannot be restored.
+	_, err = extendTTL(rpc, []xdr.LedgerKey{expired, live}, true)
+	require.True(t, errors.As(err, &archivedErr))
+	assert.Equal(t, []xdr.LedgerKey{expired}, archivedErr.Keys)
+	assert.Empty(t, server.sent())
+
+	server.reset()
+	result, err := extendTTL(rpc, []xdr.LedgerKey{archived, live}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "tx2", result.Hash)
+	assert.Equal(t, []string{
+		protocol.GetLedgerEntriesMethodName,
+		protocol.SimulateTransactionMethodName,
+		protocol.SendTransactionMethodName,
+		protocol.GetTransactionMethodName,
+		protocol.SimulateTransactionMethodName,
+		protocol.SendTransactionMethodName,
+		protocol.GetTransactionMethodName,
+	}, server.calls())
+
+	envelopes := server.sent()
+	require.Len(t, envelopes, 2)
+	restoreTx := envelopes[0].V1.Tx
+	assert.Equal(t, xdr.OperationTypeRestoreFootprint, restoreTx.Operations[0].Body.Type)
+	assert.Equal(t, xdr.SequenceNumber(11), restoreTx.SeqNum)
+	assert.Equal(t, []xdr.LedgerKey{archived}, restoreTx.Ext.SorobanData.Resources.Footprint.ReadWrite)
+	extendTx := envelopes[1].V1.Tx
+	assert.Equal(t, xdr.OperationTypeExtendFootprintTtl, extendTx.Operations[0].Body.Type)
+	assert.Equal(t, xdr.SequenceNumber(12), extendTx.SeqNum)
+	assert.Equal(t, []xdr.LedgerKey{archived, live}, extendTx.Ext.SorobanData.Resources.Footprint.ReadOnly)
+}
@@ -5,11 +5,14 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+const txTimeoutSeconds = 30
+
 func buildContractTx(
 	contractAddress xdr.ScAddress,
 	sourceAccount txnbuild.Account,
 	args []xdr.ScVal,
 	functionName xdr.ScSymbol,
+	incrementSequence bool,
 ) (*txnbuild.Transaction, error) {
 
 	invokeHostOp := &txnbuild.InvokeHostFunction{
@@ -23,12 +26,22 @@ func buildContractTx(
 		},
 	}
 
+	return buildSorobanTx(sourceAccount, invokeHostOp, incrementSequence)
+}
+
+func buildSorobanTx(
+	sourceAccount txnbuild.Account,
+	op txnbuild.Operation,
+	incrementSequence bool,
+) (*txnbuild.Transaction, error) {
+
 	return txnbuild.NewTransaction(txnbuild.TransactionParams{
-		SourceAccount: sourceAccount,
+		SourceAccount:        sourceAccount,
+		IncrementSequenceNum: incrementSequence,
 		Preconditions: txnbuild.Preconditions{
-			TimeBounds: txnbuild.NewTimeout(30),
+			TimeBounds: txnbuild.NewTimeout(txTimeoutSeconds),
 		},
-		Operations: []txnbuild.Operation{invokeHostOp},
+		Operations: []txnbuild.Operation{op},
 	})
 
 }
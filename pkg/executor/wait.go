@@ -0,0 +1,228 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+	soroban "github.com/tryoutbounder/soroban-client-golang/pkg/rpc"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
+)
+
+const (
+	DefaultPollInterval    = time.Second
+	DefaultMaxPollInterval = 5 * time.Second
+)
+
+// ErrTransactionExpired is returned when the network closed a ledger past the
+// transaction's upper time bound without including it.
+var ErrTransactionExpired = errors.New("transaction expired before it was included in a ledger")
+
+// TransactionResult is the outcome of a transaction that made it into a ledger.
+type TransactionResult struct {
+	Hash   string
+	Ledger uint32
+	// ReturnValue is the value returned by the invoked contract function, if any.
+	ReturnValue *xdr.ScVal
+	// Events are the contract events emitted by the transaction's operation.
+	Events []xdr.ContractEvent
+	Meta   xdr.TransactionMeta
+	// Response is the raw getTransaction response the result was decoded from.
+	Response protocol.GetTransactionResponse
+}
+
+// InvokeContractCall prepares, signs and submits a contract invocation, then
+// waits until it lands and returns the decoded result.
+func InvokeContractCall(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	contractAddress xdr.ScAddress,
+	sourceAccount txnbuild.Account,
+	args []xdr.ScVal,
+	functionName xdr.ScSymbol,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	transactionXdr, err := prepareContractCallWithRestore(
+		ctx,
+		rpc,
+		contractAddress,
+		sourceAccount,
+		args,
+		functionName,
+		networkPassphrase,
+		signingKeypairs,
+		opts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return SubmitAndWait(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs, opts)
+}
+
+// SubmitAndWait signs and submits an assembled transaction, then polls
+// getTransaction until it succeeds, fails or its time bounds expire.
+// TRY_AGAIN_LATER responses are resubmitted with backoff.
+func SubmitAndWait(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	transactionXdr *txnbuild.Transaction,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	hash, err := submitTransaction(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return WaitForTransaction(ctx, rpc, hash, transactionXdr.Timebounds().MaxTime, opts)
+}
+
+// submitTransaction signs and submits an assembled transaction and returns its
+// hash once the server has accepted it as PENDING or DUPLICATE.
+// TRY_AGAIN_LATER responses are resubmitted with backoff until the
+// transaction's time bounds expire.
+func submitTransaction(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	transactionXdr *txnbuild.Transaction,
+	networkPassphrase string,
+	signingKeypairs []*keypair.Full,
+	opts SubmitOptions,
+) (string, error) {
+	maxTime := transactionXdr.Timebounds().MaxTime
+	interval := pollInterval(opts)
+
+	var response protocol.SendTransactionResponse
+	for {
+		var err error
+		response, err = signAndSend(ctx, rpc, transactionXdr, networkPassphrase, signingKeypairs)
+		if err != nil {
+			return "", err
+		}
+
+		if response.Status != protocol.SendTransactionStatusTryAgainLater {
+			break
+		}
+
+		if expired(maxTime, response.LatestLedgerCloseTime) {
+			return "", fmt.Errorf("%w: %s", ErrTransactionExpired, response.Hash)
+		}
+		if err := soroban.SleepContext(ctx, interval); err != nil {
+			return "", err
+		}
+		interval = nextPollInterval(interval, opts)
+	}
+
+	switch response.Status {
+	case protocol.SendTransactionStatusPending, protocol.SendTransactionStatusDuplicate:
+		return response.Hash, nil
+	case protocol.SendTransactionStatusError:
+		return "", newSendTransactionError(response)
+	default:
+		return "", fmt.Errorf("unexpected send transaction status %q for %s", response.Status, response.Hash)
+	}
+}
+
+// WaitForTransaction polls getTransaction for hash until it reaches a final
+// status. maxTime is the transaction's upper time bound as a unix timestamp;
+// once the network closes a ledger after it without including the
+// transaction, ErrTransactionExpired is returned. A maxTime of zero waits
+// until ctx ends.
+func WaitForTransaction(
+	ctx context.Context,
+	rpc *soroban.RpcClient,
+	hash string,
+	maxTime int64,
+	opts SubmitOptions,
+) (*TransactionResult, error) {
+	interval := pollInterval(opts)
+
+	for {
+		response, err := rpc.GetTransaction(ctx, protocol.GetTransactionRequest{Hash: hash})
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.Status {
+		case protocol.TransactionStatusSuccess:
+			return decodeTransactionResult(hash, response)
+		case protocol.TransactionStatusFailed:
+			var meta *xdr.TransactionMeta
+			if response.ResultMetaXDR != "" || len(response.ResultMetaJSON) > 0 {
+				meta = &xdr.TransactionMeta{}
+				if err := xdrjson.Decode(response.ResultMetaXDR, response.ResultMetaJSON, meta); err != nil {
+					return nil, fmt.Errorf("error decoding result meta for %s: %w", hash, err)
+				}
+			}
+			return nil, newTransactionFailedError(hash, response, meta)
+		case protocol.TransactionStatusNotFound:
+			if expired(maxTime, response.LatestLedgerCloseTime) {
+				return nil, fmt.Errorf("%w: %s", ErrTransactionExpired, hash)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected transaction status %q for %s", response.Status, hash)
+		}
+
+		if err := soroban.SleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+		interval = nextPollInterval(interval, opts)
+	}
+}
+
+func decodeTransactionResult(hash string, response protocol.GetTransactionResponse) (*TransactionResult, error) {
+	result := &TransactionResult{
+		Hash:     hash,
+		Ledger:   response.Ledger,
+		Response: response,
+	}
+
+	if response.ResultMetaXDR == "" && len(response.ResultMetaJSON) == 0 {
+		return result, nil
+	}
+
+	err := xdrjson.Decode(response.ResultMetaXDR, response.ResultMetaJSON, &result.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding result meta for %s: %w", hash, err)
+	}
+
+	result.ReturnValue, err = soroban.ReturnValue(result.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Events, err = result.Meta.GetContractEventsForOperation(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func expired(maxTime int64, latestLedgerCloseTime int64) bool {
+	return maxTime > 0 && latestLedgerCloseTime > maxTime
+}
+
+func pollInterval(opts SubmitOptions) time.Duration {
+	if opts.PollInterval > 0 {
+		return opts.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+func nextPollInterval(interval time.Duration, opts SubmitOptions) time.Duration {
+	maxInterval := opts.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+	return min(interval*3/2, maxInterval)
+}
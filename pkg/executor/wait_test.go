@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestWaitForTransaction(t *testing.T) {
+	returnValue := xdr.ScVal{Type: xdr.ScValTypeScvBool, B: new(bool)}
+	*returnValue.B = true
+	meta := xdr.TransactionMeta{
+		V: 4,
+		V4: &xdr.TransactionMetaV4{
+			Operations:  []xdr.OperationMetaV2{{}},
+			SorobanMeta: &xdr.SorobanTransactionMetaV2{ReturnValue: &returnValue},
+		},
+	}
+	metaXdr, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	_, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.GetTransactionMethodName: sequentialResults(
+			`{"status":"NOT_FOUND","latestLedgerCloseTime":"100"}`,
+			fmt.Sprintf(`{"status":"SUCCESS","ledger":12,"resultMetaXdr":%q}`, metaXdr),
+		),
+	})
+
+	opts := SubmitOptions{PollInterval: time.Millisecond}
+	result, err := WaitForTransaction(context.Background(), rpc, "abcd", 200, opts)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(12), result.Ledger)
+	require.NotNil(t, result.ReturnValue)
+	assert.True(t, result.ReturnValue.Equals(returnValue))
+}
+
+func TestWaitForTransactionExpires(t *testing.T) {
+	_, rpc := newMockRPC(t, map[string]rpcResult{
+		protocol.GetTransactionMethodName: sequentialResults(`{"status":"NOT_FOUND","latestLedgerCloseTime":"300"}`),
+	})
+
+	opts := SubmitOptions{PollInterval: time.Millisecond}
+	_, err := WaitForTransaction(context.Background(), rpc, "abcd", 200, opts)
+	assert.True(t, errors.Is(err, ErrTransactionExpired))
+}
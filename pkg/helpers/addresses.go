@@ -7,47 +7,164 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
-func ContractAddressToScAddress(tokenContractStr string) (xdr.ScAddress, error) {
-	var contractAddress xdr.ScAddress
-	tokenAddress, err := strkey.Decode(strkey.VersionByteContract, tokenContractStr)
-	if err != nil {
-		return contractAddress, fmt.Errorf("error decoding token contract: %v", err)
+var versionByteNames = map[strkey.VersionByte]string{
+	strkey.VersionByteAccountID:        "account (G)",
+	strkey.VersionByteContract:         "contract (C)",
+	strkey.VersionByteMuxedAccount:     "muxed account (M)",
+	strkey.VersionByteClaimableBalance: "claimable balance (B)",
+	strkey.VersionByteLiquidityPool:    "liquidity pool (L)",
+	strkey.VersionByteSeed:             "secret seed (S)",
+	strkey.VersionByteHashTx:           "pre-authorized transaction (T)",
+	strkey.VersionByteHashX:            "hash-x (X)",
+	strkey.VersionByteSignedPayload:    "signed payload (P)",
+}
+
+func versionByteName(version strkey.VersionByte) string {
+	if name, ok := versionByteNames[version]; ok {
+		return name
 	}
+	return fmt.Sprintf("unknown version byte %d", version)
+}
 
-	var tokenAddressHash xdr.ContractId
-	copy(tokenAddressHash[:], tokenAddress)
+// AddressVersionError is returned when a strkey is valid but of a kind the
+// caller cannot use, such as an account where a contract was expected.
+type AddressVersionError struct {
+	Address  string
+	Expected []strkey.VersionByte
+	Actual   strkey.VersionByte
+}
 
-	contractAddress, err = xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeContract, tokenAddressHash)
-	if err != nil {
-		return contractAddress, fmt.Errorf("error creating contract address: %v", err)
+func (e *AddressVersionError) Error() string {
+	expected := ""
+	for idx, version := range e.Expected {
+		if idx > 0 {
+			expected += " or "
+		}
+		expected += versionByteName(version)
 	}
+	return fmt.Sprintf("expected %s strkey, got %s: %s", expected, versionByteName(e.Actual), e.Address)
+}
 
-	return contractAddress, nil
+// ParseScAddress parses any strkey that names an ScAddress: an account (G),
+// contract (C), muxed account (M), claimable balance (B) or liquidity pool
+// (L).
+func ParseScAddress(address string) (xdr.ScAddress, error) {
+	return parseScAddress(address,
+		strkey.VersionByteAccountID,
+		strkey.VersionByteContract,
+		strkey.VersionByteMuxedAccount,
+		strkey.VersionByteClaimableBalance,
+		strkey.VersionByteLiquidityPool,
+	)
 }
 
-func StellarAddressToScAddress(address string) (xdr.ScAddress, error) {
-	var scAddress xdr.ScAddress
-	// Decode the address string to get the raw bytes
-	addressBytes, err := strkey.Decode(strkey.VersionByteAccountID, address)
+// parseScAddress parses address, failing with an *AddressVersionError unless
+// it has one of the expected version bytes.
+func parseScAddress(address string, expected ...strkey.VersionByte) (xdr.ScAddress, error) {
+	version, payload, err := strkey.DecodeAny(address)
 	if err != nil {
-		return scAddress, err
+		return xdr.ScAddress{}, fmt.Errorf("invalid strkey %q: %w", address, err)
+	}
+
+	allowed := false
+	for _, candidate := range expected {
+		allowed = allowed || candidate == version
+	}
+	if !allowed {
+		return xdr.ScAddress{}, &AddressVersionError{Address: address, Expected: expected, Actual: version}
+	}
+
+	switch version {
+	case strkey.VersionByteAccountID:
+		var key xdr.Uint256
+		copy(key[:], payload)
+		accountID, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, key)
+		if err != nil {
+			return xdr.ScAddress{}, err
+		}
+		return xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeAccount, accountID)
+
+	case strkey.VersionByteContract:
+		var contractID xdr.ContractId
+		copy(contractID[:], payload)
+		return xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeContract, contractID)
+
+	case strkey.VersionByteMuxedAccount:
+		muxed, err := xdr.AddressToMuxedAccount(address)
+		if err != nil {
+			return xdr.ScAddress{}, err
+		}
+		med25519 := muxed.MustMed25519()
+		return xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeMuxedAccount, xdr.MuxedEd25519Account{
+			Id:      med25519.Id,
+			Ed25519: med25519.Ed25519,
+		})
+
+	case strkey.VersionByteClaimableBalance:
+		var balanceID xdr.ClaimableBalanceId
+		if err := balanceID.DecodeFromStrkey(address); err != nil {
+			return xdr.ScAddress{}, err
+		}
+		return xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeClaimableBalance, balanceID)
+
+	case strkey.VersionByteLiquidityPool:
+		var poolID xdr.PoolId
+		copy(poolID[:], payload)
+		return xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeLiquidityPool, poolID)
+
+	default:
+		return xdr.ScAddress{}, &AddressVersionError{Address: address, Expected: expected, Actual: version}
 	}
+}
 
-	// Convert to Uint256 for the AccountId
-	var addressUint256 xdr.Uint256
-	copy(addressUint256[:], addressBytes)
+// EncodeScAddress returns the strkey of any ScAddress, the inverse of
+// ParseScAddress.
+func EncodeScAddress(address xdr.ScAddress) (string, error) {
+	switch address.Type {
+	case xdr.ScAddressTypeScAddressTypeAccount:
+		if address.AccountId == nil {
+			return "", fmt.Errorf("account address has no account id")
+		}
+	case xdr.ScAddressTypeScAddressTypeContract:
+		if address.ContractId == nil {
+			return "", fmt.Errorf("contract address has no contract id")
+		}
+	case xdr.ScAddressTypeScAddressTypeMuxedAccount:
+		if address.MuxedAccount == nil {
+			return "", fmt.Errorf("muxed account address has no account")
+		}
+	case xdr.ScAddressTypeScAddressTypeClaimableBalance:
+		if address.ClaimableBalanceId == nil {
+			return "", fmt.Errorf("claimable balance address has no balance id")
+		}
+	case xdr.ScAddressTypeScAddressTypeLiquidityPool:
+		if address.LiquidityPoolId == nil {
+			return "", fmt.Errorf("liquidity pool address has no pool id")
+		}
+	default:
+		return "", fmt.Errorf("unknown address type %d", address.Type)
+	}
 
-	balanceAccount, err := xdr.NewAccountId(xdr.PublicKeyTypePublicKeyTypeEd25519, addressUint256)
+	encoded, err := address.String()
 	if err != nil {
-		return scAddress, err
+		return "", fmt.Errorf("failed to encode address: %w", err)
 	}
+	return encoded, nil
+}
 
-	scAddress, err = xdr.NewScAddress(xdr.ScAddressTypeScAddressTypeAccount, balanceAccount)
+// ContractAddressToScAddress parses a contract (C) strkey.
+func ContractAddressToScAddress(tokenContractStr string) (xdr.ScAddress, error) {
+	contractAddress, err := parseScAddress(tokenContractStr, strkey.VersionByteContract)
 	if err != nil {
-		return scAddress, err
+		return contractAddress, fmt.Errorf("error decoding token contract: %w", err)
 	}
 
-	return scAddress, nil
+	return contractAddress, nil
+}
+
+// StellarAddressToScAddress parses an account (G) strkey.
+func StellarAddressToScAddress(address string) (xdr.ScAddress, error) {
+	return parseScAddress(address, strkey.VersionByteAccountID)
 }
 
 func EncodeContractAddress(contractId xdr.ContractId) (string, error) {
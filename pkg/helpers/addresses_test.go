@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScAddressRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	account := strkey.MustEncode(strkey.VersionByteAccountID, key)
+	contract := strkey.MustEncode(strkey.VersionByteContract, key)
+	muxed := strkey.MustEncode(strkey.VersionByteMuxedAccount, append(append([]byte{}, key...), 0, 0, 0, 0, 0, 0, 0, 42))
+	claimable := strkey.MustEncode(strkey.VersionByteClaimableBalance, append([]byte{0}, key...))
+	pool := strkey.MustEncode(strkey.VersionByteLiquidityPool, key)
+
+	for address, addressType := range map[string]xdr.ScAddressType{
+		account:   xdr.ScAddressTypeScAddressTypeAccount,
+		contract:  xdr.ScAddressTypeScAddressTypeContract,
+		muxed:     xdr.ScAddressTypeScAddressTypeMuxedAccount,
+		claimable: xdr.ScAddressTypeScAddressTypeClaimableBalance,
+		pool:      xdr.ScAddressTypeScAddressTypeLiquidityPool,
+	} {
+		parsed, err := ParseScAddress(address)
+		require.NoError(t, err, address)
+		assert.Equal(t, addressType, parsed.Type, address)
+
+		encoded, err := EncodeScAddress(parsed)
+		require.NoError(t, err, address)
+		assert.Equal(t, address, encoded)
+	}
+
+	parsed, err := ParseScAddress(muxed)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Uint64(42), parsed.MuxedAccount.Id)
+
+	_, err = EncodeScAddress(xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract})
+	assert.Error(t, err)
+}
+
+func TestScAddressVersionErrors(t *testing.T) {
+	account := strkey.MustEncode(strkey.VersionByteAccountID, bytes.Repeat([]byte{1}, 32))
+
+	_, err := ContractAddressToScAddress(account)
+	var versionErr *AddressVersionError
+	require.True(t, errors.As(err, &versionErr))
+	assert.Equal(t, strkey.VersionByteAccountID, versionErr.Actual)
+	assert.Contains(t, err.Error(), "expected contract (C) strkey, got account (G)")
+
+	seed := strkey.MustEncode(strkey.VersionByteSeed, bytes.Repeat([]byte{1}, 32))
+	_, err = ParseScAddress(seed)
+	require.True(t, errors.As(err, &versionErr))
+	assert.Equal(t, strkey.VersionByte(strkey.VersionByteSeed), versionErr.Actual)
+
+	_, err = ParseScAddress("GNOTASTRKEY")
+	assert.Error(t, err)
+}
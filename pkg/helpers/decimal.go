@@ -0,0 +1,289 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/stellar/go/xdr"
+)
+
+// RoundingMode selects how a Decimal operation rounds a result that does not
+// fit its scale.
+type RoundingMode int
+
+const (
+	// RoundFloor rounds toward negative infinity, like Soroban's
+	// fixed_mul_floor and fixed_div_floor.
+	RoundFloor RoundingMode = iota
+	// RoundCeil rounds toward positive infinity, like fixed_mul_ceil and
+	// fixed_div_ceil.
+	RoundCeil
+	// RoundHalfEven rounds to the nearest value, and ties to the even one.
+	RoundHalfEven
+)
+
+// ErrDivisionByZero is returned when dividing by a zero Decimal.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Decimal is an exact fixed point number: an integer amount of units of
+// 10^-scale, such as stroops for a 7 decimal token. Decimals are immutable;
+// operations return new values. The zero value is 0 with scale 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    uint8
+}
+
+// NewDecimal returns unscaled * 10^-scale.
+func NewDecimal(unscaled *big.Int, scale uint8) Decimal {
+	return Decimal{unscaled: new(big.Int).Set(unscaled), scale: scale}
+}
+
+// NewDecimalFromInt64 returns unscaled * 10^-scale.
+func NewDecimalFromInt64(unscaled int64, scale uint8) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// DecimalFromI128 interprets an i128 as an amount with the given scale, such
+// as 7 for a Stellar asset.
+func DecimalFromI128(i128 xdr.Int128Parts, scale uint8) Decimal {
+	return Decimal{unscaled: I128ToBigInt(i128), scale: scale}
+}
+
+// DecimalFromI256 interprets an i256 as an amount with the given scale.
+func DecimalFromI256(i256 xdr.Int256Parts, scale uint8) Decimal {
+	return Decimal{unscaled: I256ToBigInt(i256), scale: scale}
+}
+
+// ParseDecimal parses a decimal string such as "-12.5" exactly at the given
+// scale. It fails rather than round when s has more fractional digits.
+func ParseDecimal(s string, scale uint8) (Decimal, error) {
+	d, err := parseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if d.scale > scale {
+		return Decimal{}, fmt.Errorf("%q has more than %d decimals", s, scale)
+	}
+	return d.Rescale(scale, RoundFloor), nil
+}
+
+// parseDecimal parses s at the scale of its own fractional digits.
+func parseDecimal(s string) (Decimal, error) {
+	digits := s
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		digits = s[1:]
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" || strings.ContainsAny(whole+fraction, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(fraction) > 255 {
+		return Decimal{}, fmt.Errorf("decimal %q has too many decimals", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if strings.HasPrefix(s, "-") {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{unscaled: unscaled, scale: uint8(len(fraction))}, nil
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Unscaled returns the integer number of 10^-scale units.
+func (d Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.value())
+}
+
+func (d Decimal) Scale() uint8 {
+	return d.scale
+}
+
+// I128 returns the unscaled amount as an i128.
+func (d Decimal) I128() (xdr.Int128Parts, error) {
+	return BigIntToI128(d.value())
+}
+
+// I256 returns the unscaled amount as an i256.
+func (d Decimal) I256() (xdr.Int256Parts, error) {
+	return BigIntToI256(d.value())
+}
+
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and other by value, whatever their scales.
+func (d Decimal) Cmp(other Decimal) int {
+	x, y := align(d, other)
+	return x.Cmp(y)
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+// Add returns d + other at the larger of their scales, which is exact.
+func (d Decimal) Add(other Decimal) Decimal {
+	x, y := align(d, other)
+	return Decimal{unscaled: x.Add(x, y), scale: max(d.scale, other.scale)}
+}
+
+// Sub returns d - other at the larger of their scales, which is exact.
+func (d Decimal) Sub(other Decimal) Decimal {
+	x, y := align(d, other)
+	return Decimal{unscaled: x.Sub(x, y), scale: max(d.scale, other.scale)}
+}
+
+// Mul returns d * other at d's scale, rounded with mode. For two amounts of
+// the same scale this is Soroban's fixed_mul_floor or fixed_mul_ceil with the
+// scalar as denominator.
+func (d Decimal) Mul(other Decimal, mode RoundingMode) Decimal {
+	product := new(big.Int).Mul(d.value(), other.value())
+	quotient, _ := MulDiv(product, big.NewInt(1), pow10(int(other.scale)), mode)
+	return Decimal{unscaled: quotient, scale: d.scale}
+}
+
+// Div returns d / other at d's scale, rounded with mode, like Soroban's
+// fixed_div_floor or fixed_div_ceil.
+func (d Decimal) Div(other Decimal, mode RoundingMode) (Decimal, error) {
+	quotient, err := MulDiv(d.value(), pow10(int(other.scale)), other.value(), mode)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{unscaled: quotient, scale: d.scale}, nil
+}
+
+// Rescale returns d at another scale, rounding with mode when that drops
+// digits.
+func (d Decimal) Rescale(scale uint8, mode RoundingMode) Decimal {
+	switch {
+	case scale == d.scale:
+		return d
+	case scale > d.scale:
+		unscaled := new(big.Int).Mul(d.value(), pow10(int(scale-d.scale)))
+		return Decimal{unscaled: unscaled, scale: scale}
+	default:
+		unscaled, _ := MulDiv(d.value(), big.NewInt(1), pow10(int(d.scale-scale)), mode)
+		return Decimal{unscaled: unscaled, scale: scale}
+	}
+}
+
+// Float64 returns the nearest float64, for display and estimates only.
+func (d Decimal) Float64() float64 {
+	f := new(big.Float).SetInt(d.value())
+	f.Quo(f, new(big.Float).SetInt(pow10(int(d.scale))))
+	result, _ := f.Float64()
+	return result
+}
+
+// String formats d with exactly scale fractional digits, as in "-1.5000000".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.value()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes d as a JSON string, since JSON numbers are commonly
+// read as float64.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number. The scale is the number of
+// fractional digits given, so values written by MarshalJSON round trip.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := parseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MulDiv returns x * y / denominator rounded with mode. It is the primitive
+// behind Soroban's fixed point helpers: fixed_mul_floor(x, y, d) is
+// MulDiv(x, y, d, RoundFloor) and fixed_div_floor(x, y, d) is
+// MulDiv(x, d, y, RoundFloor).
+func MulDiv(x, y, denominator *big.Int, mode RoundingMode) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	numerator := new(big.Int).Mul(x, y)
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient, nil
+	}
+
+	// QuoRem truncates toward zero; step away from zero where mode says so.
+	negative := (numerator.Sign() < 0) != (denominator.Sign() < 0)
+	step := big.NewInt(1)
+	if negative {
+		step.SetInt64(-1)
+	}
+
+	switch mode {
+	case RoundFloor:
+		if negative {
+			quotient.Add(quotient, step)
+		}
+	case RoundCeil:
+		if !negative {
+			quotient.Add(quotient, step)
+		}
+	case RoundHalfEven:
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+		switch twice.Cmp(new(big.Int).Abs(denominator)) {
+		case 1:
+			quotient.Add(quotient, step)
+		case 0:
+			if quotient.Bit(0) == 1 {
+				quotient.Add(quotient, step)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown rounding mode %d", mode)
+	}
+
+	return quotient, nil
+}
+
+// align returns the unscaled values of a and b at their common scale.
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	scale := max(a.scale, b.scale)
+	x := new(big.Int).Mul(a.value(), pow10(int(scale-a.scale)))
+	y := new(big.Int).Mul(b.value(), pow10(int(scale-b.scale)))
+	return x, y
+}
@@ -0,0 +1,106 @@
+package helpers
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulDivRounding(t *testing.T) {
+	for _, tc := range []struct {
+		numerator int64
+		mode      RoundingMode
+		expected  int64
+	}{
+		{7, RoundFloor, 3},
+		{7, RoundCeil, 4},
+		{7, RoundHalfEven, 4},
+		{-7, RoundFloor, -4},
+		{-7, RoundCeil, -3},
+		{-7, RoundHalfEven, -4},
+		{5, RoundHalfEven, 2},
+		{-5, RoundHalfEven, -2},
+		{3, RoundHalfEven, 2},
+		{6, RoundCeil, 3},
+	} {
+		result, err := MulDiv(big.NewInt(tc.numerator), big.NewInt(1), big.NewInt(2), tc.mode)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, result.Int64(), "%d / 2 with mode %d", tc.numerator, tc.mode)
+	}
+
+	_, err := MulDiv(big.NewInt(1), big.NewInt(1), big.NewInt(0), RoundFloor)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	amount := mustDecimal(t, "10.0000001", 7)
+	rate := mustDecimal(t, "0.3333333", 7)
+
+	// fixed_mul_floor(100000001, 3333333, 1e7) = 33333330
+	assert.Equal(t, "3.3333330", amount.Mul(rate, RoundFloor).String())
+	assert.Equal(t, "3.3333331", amount.Mul(rate, RoundCeil).String())
+
+	quotient, err := amount.Div(mustDecimal(t, "3", 0), RoundCeil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.3333334", quotient.String())
+
+	_, err = amount.Div(Decimal{}, RoundFloor)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	sum := amount.Add(mustDecimal(t, "0.000000000000000001", 18))
+	assert.Equal(t, uint8(18), sum.Scale())
+	assert.Equal(t, "10.000000100000000001", sum.String())
+	assert.Equal(t, 1, sum.Cmp(amount))
+	assert.Equal(t, "-0.0000001", NewDecimalFromInt64(-1, 7).String())
+	assert.Equal(t, "-10.0000001", amount.Neg().String())
+	assert.Equal(t, "10.00000", amount.Rescale(5, RoundHalfEven).String())
+
+	_, err = ParseDecimal("1.00000001", 7)
+	assert.Error(t, err)
+	_, err = ParseDecimal("1.2.3", 7)
+	assert.Error(t, err)
+}
+
+func TestDecimalConversions(t *testing.T) {
+	huge, ok := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	require.True(t, ok)
+	d := NewDecimal(huge, 18)
+
+	i128, err := d.I128()
+	require.NoError(t, err)
+	assert.Equal(t, d, DecimalFromI128(i128, 18))
+
+	_, err = NewDecimal(new(big.Int).Add(huge, big.NewInt(1)), 18).I128()
+	assert.Error(t, err)
+
+	data, err := json.Marshal(struct{ Amount Decimal }{d})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Amount":"170141183460469231731.687303715884105727"}`, string(data))
+
+	var decoded struct{ Amount Decimal }
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 0, d.Cmp(decoded.Amount))
+	assert.Equal(t, d.Scale(), decoded.Amount.Scale())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"Amount":1.25}`), &decoded))
+	assert.Equal(t, "1.25", decoded.Amount.String())
+
+	val, err := MarshalScVal(struct {
+		Amount Decimal `soroban:"amount,decimals=7"`
+	}{mustDecimal(t, "1.5", 1)})
+	require.NoError(t, err)
+	var out struct {
+		Amount Decimal `soroban:"amount,i128,decimals=7"`
+	}
+	require.NoError(t, UnmarshalScVal(val, &out))
+	assert.Equal(t, "1.5000000", out.Amount.String())
+}
+
+func mustDecimal(t *testing.T, s string, scale uint8) Decimal {
+	d, err := ParseDecimal(s, scale)
+	require.NoError(t, err)
+	return d
+}
@@ -0,0 +1,141 @@
+package helpers
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/go/xdr"
+)
+
+func I128ToBigInt(i128 xdr.Int128Parts) *big.Int {
+	value := big.NewInt(int64(i128.Hi))
+	value.Lsh(value, 64)
+	return value.Add(value, new(big.Int).SetUint64(uint64(i128.Lo)))
+}
+
+// I128ToFloat64 converts a fixed point i128 with the given scalar (e.g. 1e7
+// for 7 decimals) to a float64.
+func I128ToFloat64(i128 xdr.Int128Parts, scalar int64) float64 {
+	value := new(big.Float).SetInt(I128ToBigInt(i128))
+	value.Quo(value, new(big.Float).SetInt64(scalar))
+	result, _ := value.Float64()
+	return result
+}
+
+// I128ToInt64 truncates an i128 to its low 64 bits as a signed integer.
+func I128ToInt64(i128 xdr.Int128Parts) int64 {
+	return I128ToBigInt(i128).Int64()
+}
+
+var (
+	two64  = new(big.Int).Lsh(big.NewInt(1), 64)
+	two128 = new(big.Int).Lsh(big.NewInt(1), 128)
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// words splits a non-negative value below 2^(64*n) into n 64-bit words,
+// most significant first.
+func words(value *big.Int, n int) []uint64 {
+	result := make([]uint64, n)
+	rest := new(big.Int).Set(value)
+	word := new(big.Int)
+	for idx := n - 1; idx >= 0; idx-- {
+		rest.DivMod(rest, two64, word)
+		result[idx] = word.Uint64()
+	}
+	return result
+}
+
+func fromWords(hi *big.Int, lower ...uint64) *big.Int {
+	value := new(big.Int).Set(hi)
+	for _, word := range lower {
+		value.Lsh(value, 64)
+		value.Add(value, new(big.Int).SetUint64(word))
+	}
+	return value
+}
+
+// twosComplement maps a signed value into [0, modulus), failing if it does
+// not fit in kind.
+func twosComplement(value *big.Int, modulus *big.Int, kind string) (*big.Int, error) {
+	half := new(big.Int).Rsh(modulus, 1)
+	if value.Cmp(half) >= 0 || value.Cmp(new(big.Int).Neg(half)) < 0 {
+		return nil, fmt.Errorf("%s does not fit in %s", value, kind)
+	}
+	if value.Sign() < 0 {
+		return new(big.Int).Add(value, modulus), nil
+	}
+	return value, nil
+}
+
+func checkUnsigned(value *big.Int, modulus *big.Int, kind string) error {
+	if value.Sign() < 0 || value.Cmp(modulus) >= 0 {
+		return fmt.Errorf("%s does not fit in %s", value, kind)
+	}
+	return nil
+}
+
+// BigIntToI128 converts value to an i128, failing if it is out of range.
+func BigIntToI128(value *big.Int) (xdr.Int128Parts, error) {
+	unsigned, err := twosComplement(value, two128, "i128")
+	if err != nil {
+		return xdr.Int128Parts{}, err
+	}
+	w := words(unsigned, 2)
+	return xdr.Int128Parts{Hi: xdr.Int64(w[0]), Lo: xdr.Uint64(w[1])}, nil
+}
+
+func U128ToBigInt(u128 xdr.UInt128Parts) *big.Int {
+	return fromWords(new(big.Int).SetUint64(uint64(u128.Hi)), uint64(u128.Lo))
+}
+
+// BigIntToU128 converts value to a u128, failing if it is out of range.
+func BigIntToU128(value *big.Int) (xdr.UInt128Parts, error) {
+	if err := checkUnsigned(value, two128, "u128"); err != nil {
+		return xdr.UInt128Parts{}, err
+	}
+	w := words(value, 2)
+	return xdr.UInt128Parts{Hi: xdr.Uint64(w[0]), Lo: xdr.Uint64(w[1])}, nil
+}
+
+func I256ToBigInt(i256 xdr.Int256Parts) *big.Int {
+	return fromWords(big.NewInt(int64(i256.HiHi)), uint64(i256.HiLo), uint64(i256.LoHi), uint64(i256.LoLo))
+}
+
+// BigIntToI256 converts value to an i256, failing if it is out of range.
+func BigIntToI256(value *big.Int) (xdr.Int256Parts, error) {
+	unsigned, err := twosComplement(value, two256, "i256")
+	if err != nil {
+		return xdr.Int256Parts{}, err
+	}
+	w := words(unsigned, 4)
+	return xdr.Int256Parts{
+		HiHi: xdr.Int64(w[0]),
+		HiLo: xdr.Uint64(w[1]),
+		LoHi: xdr.Uint64(w[2]),
+		LoLo: xdr.Uint64(w[3]),
+	}, nil
+}
+
+func U256ToBigInt(u256 xdr.UInt256Parts) *big.Int {
+	return fromWords(
+		new(big.Int).SetUint64(uint64(u256.HiHi)),
+		uint64(u256.HiLo),
+		uint64(u256.LoHi),
+		uint64(u256.LoLo),
+	)
+}
+
+// BigIntToU256 converts value to a u256, failing if it is out of range.
+func BigIntToU256(value *big.Int) (xdr.UInt256Parts, error) {
+	if err := checkUnsigned(value, two256, "u256"); err != nil {
+		return xdr.UInt256Parts{}, err
+	}
+	w := words(value, 4)
+	return xdr.UInt256Parts{
+		HiHi: xdr.Uint64(w[0]),
+		HiLo: xdr.Uint64(w[1]),
+		LoHi: xdr.Uint64(w[2]),
+		LoLo: xdr.Uint64(w[3]),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import "github.com/stellar/go/xdr"
+
+// ScError type and code names, as the stellar-xdr JSON encoding spells them.
+var (
+	scErrorTypeNames = map[xdr.ScErrorType]string{
+		xdr.ScErrorTypeSceContract: "contract",
+		xdr.ScErrorTypeSceWasmVm:   "wasm_vm",
+		xdr.ScErrorTypeSceContext:  "context",
+		xdr.ScErrorTypeSceStorage:  "storage",
+		xdr.ScErrorTypeSceObject:   "object",
+		xdr.ScErrorTypeSceCrypto:   "crypto",
+		xdr.ScErrorTypeSceEvents:   "events",
+		xdr.ScErrorTypeSceBudget:   "budget",
+		xdr.ScErrorTypeSceValue:    "value",
+		xdr.ScErrorTypeSceAuth:     "auth",
+	}
+	scErrorCodeNames = map[xdr.ScErrorCode]string{
+		xdr.ScErrorCodeScecArithDomain:    "arith_domain",
+		xdr.ScErrorCodeScecIndexBounds:    "index_bounds",
+		xdr.ScErrorCodeScecInvalidInput:   "invalid_input",
+		xdr.ScErrorCodeScecMissingValue:   "missing_value",
+		xdr.ScErrorCodeScecExistingValue:  "existing_value",
+		xdr.ScErrorCodeScecExceededLimit:  "exceeded_limit",
+		xdr.ScErrorCodeScecInvalidAction:  "invalid_action",
+		xdr.ScErrorCodeScecInternalError:  "internal_error",
+		xdr.ScErrorCodeScecUnexpectedType: "unexpected_type",
+		xdr.ScErrorCodeScecUnexpectedSize: "unexpected_size",
+	}
+)
+
+// ScErrorTypeName returns the name of errorType, e.g. "wasm_vm", and false
+// if it is not a known type.
+func ScErrorTypeName(errorType xdr.ScErrorType) (string, bool) {
+	name, ok := scErrorTypeNames[errorType]
+	return name, ok
+}
+
+// ParseScErrorType returns the error type called name, and false if there is
+// none.
+func ParseScErrorType(name string) (xdr.ScErrorType, bool) {
+	for errorType, typeName := range scErrorTypeNames {
+		if typeName == name {
+			return errorType, true
+		}
+	}
+	return 0, false
+}
+
+// ScErrorCodeName returns the name of code, e.g. "invalid_input", and false
+// if it is not a known code.
+func ScErrorCodeName(code xdr.ScErrorCode) (string, bool) {
+	name, ok := scErrorCodeNames[code]
+	return name, ok
+}
+
+// ParseScErrorCode returns the error code called name, and false if there is
+// none.
+func ParseScErrorCode(name string) (xdr.ScErrorCode, bool) {
+	for code, codeName := range scErrorCodeNames {
+		if codeName == name {
+			return code, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,30 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScErrorNamesRoundTrip(t *testing.T) {
+	for errorType := int32(0); xdr.ScErrorType(0).ValidEnum(errorType); errorType++ {
+		name, ok := ScErrorTypeName(xdr.ScErrorType(errorType))
+		assert.True(t, ok, "type %d", errorType)
+		parsed, ok := ParseScErrorType(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, xdr.ScErrorType(errorType), parsed)
+	}
+	for code := int32(0); xdr.ScErrorCode(0).ValidEnum(code); code++ {
+		name, ok := ScErrorCodeName(xdr.ScErrorCode(code))
+		assert.True(t, ok, "code %d", code)
+		parsed, ok := ParseScErrorCode(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, xdr.ScErrorCode(code), parsed)
+	}
+
+	_, ok := ParseScErrorType("nope")
+	assert.False(t, ok)
+	_, ok = ScErrorCodeName(xdr.ScErrorCode(-1))
+	assert.False(t, ok)
+}
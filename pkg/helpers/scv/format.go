@@ -0,0 +1,294 @@
+package scv
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// Format renders val in a compact text syntax that Parse reads back:
+//
+//	true, false, void
+//	5u32, -5i32, 5u64, -5i64, 5u128, -5i128, 5u256, -5i256
+//	timepoint(1700000000), duration(3600)
+//	0xdeadbeef                          bytes
+//	"text"                              string, Go quoted
+//	amount, sym("true")                 symbol, quoted when not a plain word
+//	GA..., CA..., MA..., BA..., LA...   address, as its strkey
+//	[1u32, 2u32]                        vec
+//	{amount: 100i128, user: G...}       map
+//	error(contract:3), error(budget:exceeded_limit)
+//	instance(wasm:0x..., {key: val}), instance(stellar_asset)
+//	ledger_key_instance, nonce(42)
+//
+// Values that cannot be encoded, such as nil pointers, render as <invalid ...>
+// and do not parse.
+func Format(val xdr.ScVal) string {
+	var b strings.Builder
+	formatScVal(&b, val)
+	return b.String()
+}
+
+// FormatValue is Format for a built Value, rendering a build error as
+// <error: ...>.
+func FormatValue(v Value) string {
+	if v.err != nil {
+		return fmt.Sprintf("<error: %v>", v.err)
+	}
+	return Format(v.val)
+}
+
+var (
+	integerSuffixes = map[xdr.ScValType]string{
+		xdr.ScValTypeScvU32:  "u32",
+		xdr.ScValTypeScvI32:  "i32",
+		xdr.ScValTypeScvU64:  "u64",
+		xdr.ScValTypeScvI64:  "i64",
+		xdr.ScValTypeScvU128: "u128",
+		xdr.ScValTypeScvI128: "i128",
+		xdr.ScValTypeScvU256: "u256",
+		xdr.ScValTypeScvI256: "i256",
+	}
+)
+
+// keywords are the words Parse does not read as symbols.
+var keywords = map[string]bool{
+	"true":                true,
+	"false":               true,
+	"void":                true,
+	"ledger_key_instance": true,
+}
+
+var plainSymbol = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func formatScVal(b *strings.Builder, val xdr.ScVal) {
+	invalid := func() {
+		fmt.Fprintf(b, "<invalid %s>", val.Type)
+	}
+
+	switch val.Type {
+	case xdr.ScValTypeScvBool:
+		if val.B == nil {
+			invalid()
+			return
+		}
+		b.WriteString(strconv.FormatBool(*val.B))
+
+	case xdr.ScValTypeScvVoid:
+		b.WriteString("void")
+
+	case xdr.ScValTypeScvU32, xdr.ScValTypeScvI32, xdr.ScValTypeScvU64, xdr.ScValTypeScvI64,
+		xdr.ScValTypeScvU128, xdr.ScValTypeScvI128, xdr.ScValTypeScvU256, xdr.ScValTypeScvI256:
+		if !hasArm(val) {
+			invalid()
+			return
+		}
+		n, _ := helpers.ScValToBigInt(val)
+		b.WriteString(n.String())
+		b.WriteString(integerSuffixes[val.Type])
+
+	case xdr.ScValTypeScvTimepoint:
+		if val.Timepoint == nil {
+			invalid()
+			return
+		}
+		fmt.Fprintf(b, "timepoint(%d)", uint64(*val.Timepoint))
+
+	case xdr.ScValTypeScvDuration:
+		if val.Duration == nil {
+			invalid()
+			return
+		}
+		fmt.Fprintf(b, "duration(%d)", uint64(*val.Duration))
+
+	case xdr.ScValTypeScvBytes:
+		if val.Bytes == nil {
+			invalid()
+			return
+		}
+		b.WriteString("0x")
+		b.WriteString(hex.EncodeToString(*val.Bytes))
+
+	case xdr.ScValTypeScvString:
+		if val.Str == nil {
+			invalid()
+			return
+		}
+		b.WriteString(strconv.Quote(string(*val.Str)))
+
+	case xdr.ScValTypeScvSymbol:
+		if val.Sym == nil {
+			invalid()
+			return
+		}
+		formatSymbol(b, string(*val.Sym))
+
+	case xdr.ScValTypeScvAddress:
+		if val.Address == nil {
+			invalid()
+			return
+		}
+		address, err := helpers.EncodeScAddress(*val.Address)
+		if err != nil {
+			invalid()
+			return
+		}
+		b.WriteString(address)
+
+	case xdr.ScValTypeScvVec:
+		b.WriteByte('[')
+		for idx, item := range derefVec(val) {
+			if idx > 0 {
+				b.WriteString(", ")
+			}
+			formatScVal(b, item)
+		}
+		b.WriteByte(']')
+
+	case xdr.ScValTypeScvMap:
+		formatMap(b, derefMap(val))
+
+	case xdr.ScValTypeScvError:
+		if val.Error == nil {
+			invalid()
+			return
+		}
+		formatError(b, *val.Error)
+
+	case xdr.ScValTypeScvContractInstance:
+		if val.Instance == nil {
+			invalid()
+			return
+		}
+		formatInstance(b, *val.Instance)
+
+	case xdr.ScValTypeScvLedgerKeyContractInstance:
+		b.WriteString("ledger_key_instance")
+
+	case xdr.ScValTypeScvLedgerKeyNonce:
+		if val.NonceKey == nil {
+			invalid()
+			return
+		}
+		fmt.Fprintf(b, "nonce(%d)", int64(val.NonceKey.Nonce))
+
+	default:
+		fmt.Fprintf(b, "<unknown type %d>", int32(val.Type))
+	}
+}
+
+// hasArm reports whether the pointer of an integer ScVal is set.
+func hasArm(val xdr.ScVal) bool {
+	switch val.Type {
+	case xdr.ScValTypeScvU32:
+		return val.U32 != nil
+	case xdr.ScValTypeScvI32:
+		return val.I32 != nil
+	case xdr.ScValTypeScvU64:
+		return val.U64 != nil
+	case xdr.ScValTypeScvI64:
+		return val.I64 != nil
+	case xdr.ScValTypeScvU128:
+		return val.U128 != nil
+	case xdr.ScValTypeScvI128:
+		return val.I128 != nil
+	case xdr.ScValTypeScvU256:
+		return val.U256 != nil
+	case xdr.ScValTypeScvI256:
+		return val.I256 != nil
+	default:
+		return false
+	}
+}
+
+func formatSymbol(b *strings.Builder, sym string) {
+	if plainSymbol.MatchString(sym) && !keywords[sym] {
+		b.WriteString(sym)
+		return
+	}
+	fmt.Fprintf(b, "sym(%s)", strconv.Quote(sym))
+}
+
+func formatMap(b *strings.Builder, scMap xdr.ScMap) {
+	b.WriteByte('{')
+	for idx, entry := range scMap {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		formatScVal(b, entry.Key)
+		b.WriteString(": ")
+		formatScVal(b, entry.Val)
+	}
+	b.WriteByte('}')
+}
+
+func formatError(b *strings.Builder, scError xdr.ScError) {
+	typeName, ok := helpers.ScErrorTypeName(scError.Type)
+	if !ok {
+		fmt.Fprintf(b, "<invalid error type %d>", int32(scError.Type))
+		return
+	}
+
+	if scError.Type == xdr.ScErrorTypeSceContract {
+		if scError.ContractCode == nil {
+			b.WriteString("<invalid error>")
+			return
+		}
+		fmt.Fprintf(b, "error(%s:%d)", typeName, uint32(*scError.ContractCode))
+		return
+	}
+
+	if scError.Code == nil {
+		b.WriteString("<invalid error>")
+		return
+	}
+	codeName, ok := helpers.ScErrorCodeName(*scError.Code)
+	if !ok {
+		fmt.Fprintf(b, "<invalid error code %d>", int32(*scError.Code))
+		return
+	}
+	fmt.Fprintf(b, "error(%s:%s)", typeName, codeName)
+}
+
+func formatInstance(b *strings.Builder, instance xdr.ScContractInstance) {
+	b.WriteString("instance(")
+	switch instance.Executable.Type {
+	case xdr.ContractExecutableTypeContractExecutableWasm:
+		if instance.Executable.WasmHash == nil {
+			b.WriteString("<invalid executable>)")
+			return
+		}
+		b.WriteString("wasm:0x")
+		b.WriteString(hex.EncodeToString(instance.Executable.WasmHash[:]))
+	case xdr.ContractExecutableTypeContractExecutableStellarAsset:
+		b.WriteString("stellar_asset")
+	default:
+		fmt.Fprintf(b, "<unknown executable %d>)", int32(instance.Executable.Type))
+		return
+	}
+
+	if instance.Storage != nil {
+		b.WriteString(", ")
+		formatMap(b, *instance.Storage)
+	}
+	b.WriteByte(')')
+}
+
+func derefVec(val xdr.ScVal) xdr.ScVec {
+	if val.Vec == nil || *val.Vec == nil {
+		return nil
+	}
+	return **val.Vec
+}
+
+func derefMap(val xdr.ScVal) xdr.ScMap {
+	if val.Map == nil || *val.Map == nil {
+		return nil
+	}
+	return **val.Map
+}
@@ -0,0 +1,116 @@
+package scv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	user := strkey.MustEncode(strkey.VersionByteAccountID, bytes.Repeat([]byte{3}, 32))
+	muxed := strkey.MustEncode(strkey.VersionByteMuxedAccount, append(bytes.Repeat([]byte{3}, 32), 0, 0, 0, 0, 0, 0, 0, 9))
+	wasmHash := bytes.Repeat([]byte{0xab}, 32)
+	minI256 := new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	contractCode := xdr.Uint32(3)
+	budgetCode := xdr.ScErrorCodeScecExceededLimit
+
+	for _, tc := range []struct {
+		val      Value
+		expected string
+	}{
+		{Bool(true), "true"},
+		{Void(), "void"},
+		{U32(5), "5u32"},
+		{I32(-5), "-5i32"},
+		{U64(1700000000), "1700000000u64"},
+		{I64(-1), "-1i64"},
+		{U128(big.NewInt(7)), "7u128"},
+		{I128FromInt64(-100), "-100i128"},
+		{Of(struct {
+			N *big.Int `soroban:"n,u256"`
+		}{big.NewInt(1)}), "{n: 1u256}"},
+		{Raw(MustParse(minI256.String() + "i256")), minI256.String() + "i256"},
+		{Raw(MustParse("timepoint(1700000000)")), "timepoint(1700000000)"},
+		{Raw(MustParse("duration(60)")), "duration(60)"},
+		{Bytes([]byte{0xde, 0xad}), "0xdead"},
+		{Bytes(nil), "0x"},
+		{String("a \"quoted\"\nline"), `"a \"quoted\"\nline"`},
+		{Sym("amount"), "amount"},
+		{Sym("true"), `sym("true")`},
+		{Sym("1st"), `sym("1st")`},
+		{Address(user), user},
+		{Address(muxed), muxed},
+		{Vec(), "[]"},
+		{Vec(U32(1), Vec(Sym("Nested"))), "[1u32, [Nested]]"},
+		{Map(), "{}"},
+		{Map(Field("user", Address(user)), Field("amount", I128FromInt64(100))), "{amount: 100i128, user: " + user + "}"},
+		{Map(Entry{Key: U32(1), Val: Void()}), "{1u32: void}"},
+		{Raw(xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &contractCode}}), "error(contract:3)"},
+		{Raw(xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceBudget, Code: &budgetCode}}), "error(budget:exceeded_limit)"},
+		{Raw(xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}), "ledger_key_instance"},
+		{Raw(xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyNonce, NonceKey: &xdr.ScNonceKey{Nonce: -42}}), "nonce(-42)"},
+		{Raw(xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+			Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableStellarAsset},
+		}}), "instance(stellar_asset)"},
+	} {
+		val, err := tc.val.ScVal()
+		require.NoError(t, err, tc.expected)
+		assert.Equal(t, tc.expected, Format(val))
+
+		parsed, err := Parse(tc.expected)
+		require.NoError(t, err, tc.expected)
+		assertSameXDR(t, val, parsed)
+	}
+
+	var hash xdr.Hash
+	copy(hash[:], wasmHash)
+	storage := **Map(Field("Admin", Address(user))).MustScVal().Map
+	instance := xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+		Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+		Storage:    &storage,
+	}}
+	text := Format(instance)
+	assert.Equal(t, "instance(wasm:0x"+hex.EncodeToString(wasmHash)+", {Admin: "+user+"})", text)
+	assertSameXDR(t, instance, MustParse(text))
+}
+
+func TestParse(t *testing.T) {
+	val, err := Parse(" { user : 1u32 , amount : [ -7i128 , ] , } ")
+	require.NoError(t, err)
+	assert.Equal(t, "{amount: [-7i128], user: 1u32}", Format(val))
+
+	for _, input := range []string{
+		"",
+		"5",
+		"300u8",
+		"256u32x",
+		"-1u32",
+		"[1u32",
+		"{a: 1u32, a: 2u32}",
+		"0xabc",
+		"nope(1)",
+		"error(budget:bogus)",
+		"GAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		"true false",
+	} {
+		_, err := Parse(input)
+		var syntaxErr *SyntaxError
+		assert.True(t, errors.As(err, &syntaxErr), "%q: %v", input, err)
+	}
+}
+
+func assertSameXDR(t *testing.T, expected, actual xdr.ScVal) {
+	t.Helper()
+	x, err := xdr.MarshalBase64(expected)
+	require.NoError(t, err)
+	y, err := xdr.MarshalBase64(actual)
+	require.NoError(t, err)
+	assert.Equal(t, x, y, Format(expected))
+}
@@ -0,0 +1,49 @@
+package scv
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// ContractDataKey returns the ledger key of the entry contract stores under
+// key with the given durability.
+func ContractDataKey(contract string, key Value, durability xdr.ContractDataDurability) (xdr.LedgerKey, error) {
+	address, err := helpers.ContractAddressToScAddress(contract)
+	if err != nil {
+		return xdr.LedgerKey{}, err
+	}
+	val, err := key.ScVal()
+	if err != nil {
+		return xdr.LedgerKey{}, fmt.Errorf("error building contract data key: %w", err)
+	}
+
+	return xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   address,
+			Key:        val,
+			Durability: durability,
+		},
+	}, nil
+}
+
+// InstanceKey returns the ledger key of a contract's instance, which holds
+// its executable and instance storage.
+func InstanceKey(contract string) (xdr.LedgerKey, error) {
+	key := Value{val: xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}}
+	return ContractDataKey(contract, key, xdr.ContractDataDurabilityPersistent)
+}
+
+// PersistentKey returns the ledger key of an entry in a contract's
+// persistent storage.
+func PersistentKey(contract string, key Value) (xdr.LedgerKey, error) {
+	return ContractDataKey(contract, key, xdr.ContractDataDurabilityPersistent)
+}
+
+// TemporaryKey returns the ledger key of an entry in a contract's temporary
+// storage.
+func TemporaryKey(contract string, key Value) (xdr.LedgerKey, error) {
+	return ContractDataKey(contract, key, xdr.ContractDataDurabilityTemporary)
+}
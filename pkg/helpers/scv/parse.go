@@ -0,0 +1,421 @@
+package scv
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// SyntaxError reports where Parse failed.
+type SyntaxError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("scv: %s at offset %d", e.Msg, e.Offset)
+}
+
+// Parse reads a value in the syntax Format writes. Whitespace between tokens
+// is ignored, and map entries are sorted as the host requires whatever their
+// order in s.
+func Parse(s string) (xdr.ScVal, error) {
+	p := &parser{input: s}
+	val, err := p.value()
+	if err != nil {
+		return xdr.ScVal{}, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return xdr.ScVal{}, p.errorf("unexpected %q after value", p.input[p.pos:])
+	}
+	return val, nil
+}
+
+// MustParse is like Parse but panics on error, for test fixtures and other
+// constant values.
+func MustParse(s string) xdr.ScVal {
+	val, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.input) {
+			return p.errorf("expected %q, got end of input", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.input[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// take consumes the longest run of bytes accepted by ok.
+func (p *parser) take(ok func(byte) bool) string {
+	start := p.pos
+	for p.pos < len(p.input) && ok(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (p *parser) value() (xdr.ScVal, error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return xdr.ScVal{}, p.errorf("unexpected end of input")
+	case c == '[':
+		return p.vec()
+	case c == '{':
+		scMap, err := p.scMap()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvMap, &scMap)
+	case c == '"':
+		s, err := p.quoted()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvString, xdr.ScString(s))
+	case strings.HasPrefix(p.input[p.pos:], "0x"):
+		b, err := p.hexBytes()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvBytes, xdr.ScBytes(b))
+	case c == '-' || isDigit(c):
+		return p.integer()
+	case isWordByte(c):
+		return p.word()
+	default:
+		return xdr.ScVal{}, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *parser) vec() (xdr.ScVal, error) {
+	var vec xdr.ScVec
+	err := p.list('[', ']', func() error {
+		item, err := p.value()
+		if err != nil {
+			return err
+		}
+		vec = append(vec, item)
+		return nil
+	})
+	if err != nil {
+		return xdr.ScVal{}, err
+	}
+	if vec == nil {
+		vec = xdr.ScVec{}
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvVec, &vec)
+}
+
+func (p *parser) scMap() (xdr.ScMap, error) {
+	scMap := xdr.ScMap{}
+	err := p.list('{', '}', func() error {
+		offset := p.pos
+		key, err := p.value()
+		if err != nil {
+			return err
+		}
+		if err := p.expect(':'); err != nil {
+			return err
+		}
+		val, err := p.value()
+		if err != nil {
+			return err
+		}
+		for _, entry := range scMap {
+			if helpers.CompareScVal(entry.Key, key) == 0 {
+				return &SyntaxError{Offset: offset, Msg: "duplicate map key"}
+			}
+		}
+		scMap = append(scMap, xdr.ScMapEntry{Key: key, Val: val})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	helpers.SortScMap(scMap)
+	return scMap, nil
+}
+
+// list parses comma separated items between open and close, allowing a
+// trailing comma.
+func (p *parser) list(open, close byte, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for {
+		if p.peek() == close {
+			p.pos++
+			return nil
+		}
+		if err := item(); err != nil {
+			return err
+		}
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		return p.expect(close)
+	}
+}
+
+func (p *parser) quoted() (string, error) {
+	p.skipSpace()
+	prefix, err := strconv.QuotedPrefix(p.input[p.pos:])
+	if err != nil || !strings.HasPrefix(prefix, `"`) {
+		return "", p.errorf("invalid string")
+	}
+	s, err := strconv.Unquote(prefix)
+	if err != nil {
+		return "", p.errorf("invalid string: %v", err)
+	}
+	p.pos += len(prefix)
+	return s, nil
+}
+
+func (p *parser) hexBytes() ([]byte, error) {
+	p.skipSpace()
+	p.pos += len("0x")
+	digits := p.take(isWordByte)
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, p.errorf("invalid hex %q", digits)
+	}
+	return b, nil
+}
+
+func (p *parser) integer() (xdr.ScVal, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.input[p.pos] == '-' {
+		p.pos++
+	}
+	digits := p.input[start:p.pos] + p.take(isDigit)
+	suffix := p.take(isWordByte)
+
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: fmt.Sprintf("invalid integer %q", digits)}
+	}
+	for _, known := range integerSuffixes {
+		if suffix == known {
+			val, err := helpers.BigIntToScVal(n, suffix)
+			if err != nil {
+				return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: err.Error()}
+			}
+			return val, nil
+		}
+	}
+	return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: fmt.Sprintf("integer %q needs a type suffix such as i128", digits+suffix)}
+}
+
+// word parses keywords, call forms such as timepoint(5), addresses and
+// symbols.
+func (p *parser) word() (xdr.ScVal, error) {
+	start := p.pos
+	word := p.take(isWordByte)
+
+	switch word {
+	case "true", "false":
+		return xdr.NewScVal(xdr.ScValTypeScvBool, word == "true")
+	case "void":
+		return xdr.ScVal{Type: xdr.ScValTypeScvVoid}, nil
+	case "ledger_key_instance":
+		return xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, nil
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		return p.call(start, word)
+	}
+
+	// Symbols are at most 32 bytes; strkeys are longer.
+	if len(word) > 32 {
+		address, err := helpers.ParseScAddress(word)
+		if err != nil {
+			return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: err.Error()}
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvAddress, address)
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvSymbol, xdr.ScSymbol(word))
+}
+
+func (p *parser) call(start int, name string) (xdr.ScVal, error) {
+	p.pos++ // (
+
+	var val xdr.ScVal
+	var err error
+	switch name {
+	case "sym":
+		var s string
+		if s, err = p.quoted(); err == nil {
+			val, err = xdr.NewScVal(xdr.ScValTypeScvSymbol, xdr.ScSymbol(s))
+		}
+	case "timepoint", "duration":
+		var n *big.Int
+		if n, err = p.unsigned(); err == nil {
+			val, err = helpers.BigIntToScVal(n, name)
+		}
+	case "nonce":
+		val, err = p.nonce()
+	case "error":
+		val, err = p.scError()
+	case "instance":
+		val, err = p.instance()
+	default:
+		return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: fmt.Sprintf("unknown form %s(...)", name)}
+	}
+	if err != nil {
+		if _, ok := err.(*SyntaxError); !ok {
+			err = &SyntaxError{Offset: start, Msg: err.Error()}
+		}
+		return xdr.ScVal{}, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return xdr.ScVal{}, err
+	}
+	return val, nil
+}
+
+func (p *parser) unsigned() (*big.Int, error) {
+	p.skipSpace()
+	digits := p.take(isDigit)
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, p.errorf("expected an unsigned integer")
+	}
+	return n, nil
+}
+
+func (p *parser) nonce() (xdr.ScVal, error) {
+	p.skipSpace()
+	start := p.pos
+	if p.pos < len(p.input) && p.input[p.pos] == '-' {
+		p.pos++
+	}
+	digits := p.input[start:p.pos] + p.take(isDigit)
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return xdr.ScVal{}, &SyntaxError{Offset: start, Msg: fmt.Sprintf("invalid nonce %q", digits)}
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvLedgerKeyNonce, xdr.ScNonceKey{Nonce: xdr.Int64(n)})
+}
+
+func (p *parser) scError() (xdr.ScVal, error) {
+	p.skipSpace()
+	typeName := p.take(isWordByte)
+	if err := p.expect(':'); err != nil {
+		return xdr.ScVal{}, err
+	}
+
+	errorType, ok := helpers.ParseScErrorType(typeName)
+	if !ok {
+		return xdr.ScVal{}, p.errorf("unknown error type %q", typeName)
+	}
+
+	scError := xdr.ScError{Type: errorType}
+	if errorType == xdr.ScErrorTypeSceContract {
+		n, err := p.unsigned()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		if !n.IsUint64() || n.Uint64() > 0xffffffff {
+			return xdr.ScVal{}, p.errorf("contract error code %s overflows u32", n)
+		}
+		code := xdr.Uint32(n.Uint64())
+		scError.ContractCode = &code
+		return xdr.NewScVal(xdr.ScValTypeScvError, scError)
+	}
+
+	p.skipSpace()
+	codeName := p.take(isWordByte)
+	code, ok := helpers.ParseScErrorCode(codeName)
+	if !ok {
+		return xdr.ScVal{}, p.errorf("unknown error code %q", codeName)
+	}
+	scError.Code = &code
+	return xdr.NewScVal(xdr.ScValTypeScvError, scError)
+}
+
+func (p *parser) instance() (xdr.ScVal, error) {
+	var instance xdr.ScContractInstance
+
+	p.skipSpace()
+	switch kind := p.take(isWordByte); kind {
+	case "stellar_asset":
+		instance.Executable.Type = xdr.ContractExecutableTypeContractExecutableStellarAsset
+	case "wasm":
+		if err := p.expect(':'); err != nil {
+			return xdr.ScVal{}, err
+		}
+		if p.peek() != '0' {
+			return xdr.ScVal{}, p.errorf("expected a 0x wasm hash")
+		}
+		b, err := p.hexBytes()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		var hash xdr.Hash
+		if len(b) != len(hash) {
+			return xdr.ScVal{}, p.errorf("wasm hash has %d bytes, want %d", len(b), len(hash))
+		}
+		copy(hash[:], b)
+		instance.Executable.Type = xdr.ContractExecutableTypeContractExecutableWasm
+		instance.Executable.WasmHash = &hash
+	default:
+		return xdr.ScVal{}, p.errorf("unknown executable %q", kind)
+	}
+
+	if p.peek() == ',' {
+		p.pos++
+		storage, err := p.scMap()
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		instance.Storage = &storage
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvContractInstance, instance)
+}
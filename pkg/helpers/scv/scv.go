@@ -0,0 +1,194 @@
+// Package scv builds ScVals and contract data ledger keys without spelling
+// out the nested, pointer heavy XDR structs:
+//
+//	key := scv.Vec(
+//		scv.Sym("UserBalance"),
+//		scv.Map(
+//			scv.Field("pool", scv.Address(pool)),
+//			scv.Field("user", scv.Address(user)),
+//		),
+//	)
+//	ledgerKey, err := scv.PersistentKey(backstop, key)
+//
+// Constructors that can fail, such as Address on an invalid strkey, carry the
+// error inside the Value; it surfaces, with its position, when the outermost
+// value is turned into an ScVal or a ledger key.
+package scv
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+// Value is an ScVal under construction, or the error that prevented it.
+type Value struct {
+	val xdr.ScVal
+	err error
+}
+
+// ScVal returns the built value, or the first error met building it.
+func (v Value) ScVal() (xdr.ScVal, error) {
+	return v.val, v.err
+}
+
+// MustScVal is like ScVal but panics on error. It suits values built from
+// constants, such as keys in package level variables.
+func (v Value) MustScVal() xdr.ScVal {
+	if v.err != nil {
+		panic(v.err)
+	}
+	return v.val
+}
+
+// Err returns the error that prevented building v, if any.
+func (v Value) Err() error {
+	return v.err
+}
+
+func newValue(valType xdr.ScValType, value any) Value {
+	val, err := xdr.NewScVal(valType, value)
+	return Value{val: val, err: err}
+}
+
+func errorValue(err error) Value {
+	return Value{err: err}
+}
+
+// Raw wraps an existing ScVal.
+func Raw(val xdr.ScVal) Value {
+	return Value{val: val}
+}
+
+// Void is the unit value, as returned by functions without a result.
+func Void() Value {
+	return Value{val: xdr.ScVal{Type: xdr.ScValTypeScvVoid}}
+}
+
+func Bool(b bool) Value {
+	return newValue(xdr.ScValTypeScvBool, b)
+}
+
+func U32(n uint32) Value {
+	return newValue(xdr.ScValTypeScvU32, xdr.Uint32(n))
+}
+
+func I32(n int32) Value {
+	return newValue(xdr.ScValTypeScvI32, xdr.Int32(n))
+}
+
+func U64(n uint64) Value {
+	return newValue(xdr.ScValTypeScvU64, xdr.Uint64(n))
+}
+
+func I64(n int64) Value {
+	return newValue(xdr.ScValTypeScvI64, xdr.Int64(n))
+}
+
+// I128 fails when n is outside the i128 range.
+func I128(n *big.Int) Value {
+	if n == nil {
+		return errorValue(fmt.Errorf("nil i128"))
+	}
+	val, err := helpers.BigIntToScVal(n, "i128")
+	return Value{val: val, err: err}
+}
+
+// I128FromInt64 is I128 for amounts that fit an int64.
+func I128FromInt64(n int64) Value {
+	return I128(big.NewInt(n))
+}
+
+// U128 fails when n is negative or does not fit 128 bits.
+func U128(n *big.Int) Value {
+	if n == nil {
+		return errorValue(fmt.Errorf("nil u128"))
+	}
+	val, err := helpers.BigIntToScVal(n, "u128")
+	return Value{val: val, err: err}
+}
+
+// Sym builds a symbol, the type of enum variant names and struct field keys.
+func Sym(s string) Value {
+	return newValue(xdr.ScValTypeScvSymbol, xdr.ScSymbol(s))
+}
+
+func String(s string) Value {
+	return newValue(xdr.ScValTypeScvString, xdr.ScString(s))
+}
+
+func Bytes(b []byte) Value {
+	return newValue(xdr.ScValTypeScvBytes, xdr.ScBytes(b))
+}
+
+// Address parses any strkey helpers.ParseScAddress accepts.
+func Address(strkey string) Value {
+	address, err := helpers.ParseScAddress(strkey)
+	if err != nil {
+		return errorValue(err)
+	}
+	return newValue(xdr.ScValTypeScvAddress, address)
+}
+
+// ScAddress wraps an already parsed address.
+func ScAddress(address xdr.ScAddress) Value {
+	return newValue(xdr.ScValTypeScvAddress, address)
+}
+
+// Vec builds a vector of items, which is also how contracts encode tuples
+// and enum variants: Vec(Sym("Variant"), payload...).
+func Vec(items ...Value) Value {
+	vec := make(xdr.ScVec, len(items))
+	for idx, item := range items {
+		if item.err != nil {
+			return errorValue(fmt.Errorf("vec item %d: %w", idx, item.err))
+		}
+		vec[idx] = item.val
+	}
+	return newValue(xdr.ScValTypeScvVec, &vec)
+}
+
+// Entry is a key and value of a Map.
+type Entry struct {
+	Key Value
+	Val Value
+}
+
+// Field is a map entry keyed by a symbol, as contracttype structs are
+// encoded.
+func Field(name string, val Value) Entry {
+	return Entry{Key: Sym(name), Val: val}
+}
+
+// Map builds a map with its entries sorted by key, the order the host
+// requires and the only one that matches on-chain keys. Duplicate keys are
+// an error.
+func Map(entries ...Entry) Value {
+	scMap := make(xdr.ScMap, len(entries))
+	for idx, entry := range entries {
+		if entry.Key.err != nil {
+			return errorValue(fmt.Errorf("map key %d: %w", idx, entry.Key.err))
+		}
+		if entry.Val.err != nil {
+			return errorValue(fmt.Errorf("map value %d: %w", idx, entry.Val.err))
+		}
+		scMap[idx] = xdr.ScMapEntry{Key: entry.Key.val, Val: entry.Val.val}
+	}
+
+	helpers.SortScMap(scMap)
+	for idx := 1; idx < len(scMap); idx++ {
+		if helpers.CompareScVal(scMap[idx-1].Key, scMap[idx].Key) == 0 {
+			return errorValue(fmt.Errorf("duplicate map key of type %s", scMap[idx].Key.Type))
+		}
+	}
+	return newValue(xdr.ScValTypeScvMap, &scMap)
+}
+
+// Of converts a Go value with helpers.MarshalScVal, for structs with
+// soroban tags and other values the constructors do not cover.
+func Of(value any) Value {
+	val, err := helpers.MarshalScVal(value)
+	return Value{val: val, err: err}
+}
@@ -0,0 +1,100 @@
+package scv
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapSortsKeys(t *testing.T) {
+	val, err := Map(
+		Field("user", U32(2)),
+		Field("pool", U32(1)),
+		Entry{Key: U32(7), Val: Bool(true)},
+	).ScVal()
+	require.NoError(t, err)
+
+	scMap := **val.Map
+	require.Len(t, scMap, 3)
+	// u32 sorts before symbol, whatever the order given.
+	assert.Equal(t, xdr.ScValTypeScvU32, scMap[0].Key.Type)
+	assert.Equal(t, xdr.ScSymbol("pool"), *scMap[1].Key.Sym)
+	assert.Equal(t, xdr.ScSymbol("user"), *scMap[2].Key.Sym)
+
+	_, err = Map(Field("a", Void()), Field("a", Void())).ScVal()
+	assert.ErrorContains(t, err, "duplicate map key")
+}
+
+func TestErrorsPropagate(t *testing.T) {
+	_, err := Vec(Sym("Balance"), Map(Field("user", Address("nope")))).ScVal()
+	assert.ErrorContains(t, err, "vec item 1: map value 0")
+
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 127)
+	assert.Error(t, I128(tooBig).Err())
+	assert.Error(t, U128(big.NewInt(-1)).Err())
+	assert.Panics(t, func() { I128(tooBig).MustScVal() })
+}
+
+func TestPersistentKeyMatchesHandBuiltKey(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, bytes.Repeat([]byte{1}, 32))
+	pool := strkey.MustEncode(strkey.VersionByteContract, bytes.Repeat([]byte{2}, 32))
+	user := strkey.MustEncode(strkey.VersionByteAccountID, bytes.Repeat([]byte{3}, 32))
+
+	key, err := PersistentKey(contract, Vec(
+		Sym("UserBalance"),
+		Map(Field("user", Address(user)), Field("pool", Address(pool))),
+	))
+	require.NoError(t, err)
+
+	var contractID xdr.ContractId
+	copy(contractID[:], bytes.Repeat([]byte{1}, 32))
+	var poolID xdr.ContractId
+	copy(poolID[:], bytes.Repeat([]byte{2}, 32))
+	var userKey xdr.Uint256
+	copy(userKey[:], bytes.Repeat([]byte{3}, 32))
+
+	poolAddress := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &poolID}
+	userAddress := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &xdr.AccountId{
+		Type:    xdr.PublicKeyTypePublicKeyTypeEd25519,
+		Ed25519: &userKey,
+	}}
+	symbol, poolSym, userSym := xdr.ScSymbol("UserBalance"), xdr.ScSymbol("pool"), xdr.ScSymbol("user")
+	scMap := &xdr.ScMap{
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &poolSym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &poolAddress}},
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &userSym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &userAddress}},
+	}
+	vec := &xdr.ScVec{
+		{Type: xdr.ScValTypeScvSymbol, Sym: &symbol},
+		{Type: xdr.ScValTypeScvMap, Map: &scMap},
+	}
+	expected := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+
+	expectedXDR, err := xdr.MarshalBase64(expected)
+	require.NoError(t, err)
+	actualXDR, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	assert.Equal(t, expectedXDR, actualXDR)
+
+	instance, err := InstanceKey(contract)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ScValTypeScvLedgerKeyContractInstance, instance.ContractData.Key.Type)
+
+	temporary, err := TemporaryKey(contract, Sym("Nonce"))
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ContractDataDurabilityTemporary, temporary.ContractData.Durability)
+
+	_, err = PersistentKey(user, Sym("RZ"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,941 @@
+package helpers
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/stellar/go/xdr"
+)
+
+// ScValMarshaler is implemented by types that encode themselves as an ScVal,
+// such as contract enums whose variants carry values.
+type ScValMarshaler interface {
+	MarshalScVal() (xdr.ScVal, error)
+}
+
+// ScValUnmarshaler is implemented by types that decode themselves from an
+// ScVal.
+type ScValUnmarshaler interface {
+	UnmarshalScVal(val xdr.ScVal) error
+}
+
+// Symbol is a string encoded as an ScSymbol rather than an ScString.
+type Symbol string
+
+// MarshalScVal encodes s as an ScSymbol.
+func (s Symbol) MarshalScVal() (xdr.ScVal, error) {
+	return xdr.NewScVal(xdr.ScValTypeScvSymbol, xdr.ScSymbol(s))
+}
+
+// Address is a string encoded as an ScAddress. It holds any strkey
+// ParseScAddress accepts.
+type Address string
+
+// MarshalScVal encodes a as an ScAddress.
+func (a Address) MarshalScVal() (xdr.ScVal, error) {
+	address, err := ParseScAddress(string(a))
+	if err != nil {
+		return xdr.ScVal{}, err
+	}
+	return xdr.NewScVal(xdr.ScValTypeScvAddress, address)
+}
+
+// ScValError reports where in a value encoding or decoding failed. Path is
+// rooted at $, as in $.q4w[2].exp.
+type ScValError struct {
+	Path string
+	Err  error
+}
+
+func (e *ScValError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ScValError) Unwrap() error {
+	return e.Err
+}
+
+// UnmarshalScVal decodes val into the value out points to. Structs decode
+// from maps keyed by symbols, following `soroban` struct tags:
+//
+//	type Q4W struct {
+//		Amount     *big.Int  `soroban:"amount,i128"`
+//		Expiration time.Time `soroban:"exp"`
+//	}
+//
+// The tag name is the map key; without one the field name in snake_case is
+// used, and "-" skips the field. Tag options are:
+//
+//   - a type (bool, i32, u32, i64, u64, timepoint, duration, i128, u128,
+//     i256, u256, symbol, string, address, bytes, vec or map), which the
+//     value must have when decoding and is given when encoding
+//   - decimals=N, for Decimal and float fields holding fixed point integers
+//   - tuple, for struct fields encoded as a vec in field order
+//   - enum, for string fields holding a unit enum variant
+//   - required, for struct fields that must be present in the map
+//
+// Map keys without a matching field are ignored, and fields missing from
+// the map keep their zero value unless they are required.
+//
+// Pointers decode void as nil, the way contract options are encoded, and
+// encode nil as void. Nil slices and maps encode as an empty vec and map.
+// Integers, floats, *big.Int, Decimal, strings, []byte, byte arrays,
+// slices, maps, time.Time, time.Duration, xdr.ScVal and xdr.ScAddress are
+// supported, as are types implementing ScValUnmarshaler.
+func UnmarshalScVal(val xdr.ScVal, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("cannot unmarshal ScVal into %T", out)
+	}
+	return decodeScVal("$", val, v.Elem(), fieldOptions{})
+}
+
+// MarshalScVal encodes value as an ScVal, the inverse of UnmarshalScVal. Maps
+// and struct fields are sorted into the order the host expects.
+func MarshalScVal(value any) (xdr.ScVal, error) {
+	if value == nil {
+		return xdr.ScVal{Type: xdr.ScValTypeScvVoid}, nil
+	}
+	return encodeScVal("$", reflect.ValueOf(value), fieldOptions{})
+}
+
+// MarshalScValTuple encodes the fields of a struct as a vec in field order,
+// the way tuple structs, union variants and argument lists are encoded.
+func MarshalScValTuple(value any) (xdr.ScVec, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot marshal %T as a tuple", value)
+	}
+
+	val, err := encodeStruct("$", v, fieldOptions{tuple: true})
+	if err != nil {
+		return nil, err
+	}
+	return **val.Vec, nil
+}
+
+// UnmarshalScValTuple decodes vec into the fields of the struct out points
+// to, in field order. It is the inverse of MarshalScValTuple.
+func UnmarshalScValTuple(vec xdr.ScVec, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot unmarshal a tuple into %T", out)
+	}
+
+	val, err := xdr.NewScVal(xdr.ScValTypeScvVec, &vec)
+	if err != nil {
+		return err
+	}
+	return decodeStruct("$", val, v.Elem(), fieldOptions{tuple: true})
+}
+
+var (
+	scValType       = reflect.TypeFor[xdr.ScVal]()
+	scAddressType   = reflect.TypeFor[xdr.ScAddress]()
+	bigIntType      = reflect.TypeFor[big.Int]()
+	decimalType     = reflect.TypeFor[Decimal]()
+	timeType        = reflect.TypeFor[time.Time]()
+	durationType    = reflect.TypeFor[time.Duration]()
+	marshalerType   = reflect.TypeFor[ScValMarshaler]()
+	unmarshalerType = reflect.TypeFor[ScValUnmarshaler]()
+)
+
+var scValKinds = map[string]xdr.ScValType{
+	"bool":      xdr.ScValTypeScvBool,
+	"i32":       xdr.ScValTypeScvI32,
+	"u32":       xdr.ScValTypeScvU32,
+	"i64":       xdr.ScValTypeScvI64,
+	"u64":       xdr.ScValTypeScvU64,
+	"timepoint": xdr.ScValTypeScvTimepoint,
+	"duration":  xdr.ScValTypeScvDuration,
+	"i128":      xdr.ScValTypeScvI128,
+	"u128":      xdr.ScValTypeScvU128,
+	"i256":      xdr.ScValTypeScvI256,
+	"u256":      xdr.ScValTypeScvU256,
+	"symbol":    xdr.ScValTypeScvSymbol,
+	"string":    xdr.ScValTypeScvString,
+	"address":   xdr.ScValTypeScvAddress,
+	"bytes":     xdr.ScValTypeScvBytes,
+	"vec":       xdr.ScValTypeScvVec,
+	"map":       xdr.ScValTypeScvMap,
+}
+
+type fieldOptions struct {
+	kind     string
+	decimals int
+	tuple    bool
+	enum     bool
+	required bool
+}
+
+type structField struct {
+	name  string
+	index int
+	opts  fieldOptions
+}
+
+var structFieldsCache sync.Map
+
+func parseFieldOptions(options []string) (fieldOptions, error) {
+	var opts fieldOptions
+	for _, option := range options {
+		switch {
+		case option == "tuple":
+			opts.tuple = true
+		case option == "enum":
+			opts.enum = true
+		case option == "required":
+			opts.required = true
+		case strings.HasPrefix(option, "decimals="):
+			decimals, err := strconv.Atoi(strings.TrimPrefix(option, "decimals="))
+			// Decimal scales are a uint8.
+			if err != nil || decimals < 0 || decimals > math.MaxUint8 {
+				return opts, fmt.Errorf("invalid option %q", option)
+			}
+			opts.decimals = decimals
+		default:
+			if _, ok := scValKinds[option]; !ok {
+				return opts, fmt.Errorf("unknown option %q", option)
+			}
+			opts.kind = option
+		}
+	}
+	return opts, nil
+}
+
+func structFields(t reflect.Type) ([]structField, error) {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField), nil
+	}
+
+	var fields []structField
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("soroban")
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = snakeCase(field.Name)
+		}
+		opts, err := parseFieldOptions(parts[1:])
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		fields = append(fields, structField{name: name, index: idx, opts: opts})
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// snakeCase turns a Go field name such as UserID into user_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for idx, r := range runes {
+		if unicode.IsUpper(r) && idx > 0 {
+			prev := runes[idx-1]
+			nextLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// scValTypeName is the short name of an ScVal type, such as i128.
+func scValTypeName(t xdr.ScValType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "ScValTypeScv"))
+}
+
+func typeError(path string, expected string, val xdr.ScVal) error {
+	return &ScValError{Path: path, Err: fmt.Errorf("expected %s, got %s", expected, scValTypeName(val.Type))}
+}
+
+// ScValToBigInt returns the value of any integer ScVal.
+func ScValToBigInt(val xdr.ScVal) (*big.Int, bool) {
+	switch val.Type {
+	case xdr.ScValTypeScvI32:
+		return big.NewInt(int64(*val.I32)), true
+	case xdr.ScValTypeScvU32:
+		return big.NewInt(int64(*val.U32)), true
+	case xdr.ScValTypeScvI64:
+		return big.NewInt(int64(*val.I64)), true
+	case xdr.ScValTypeScvU64:
+		return new(big.Int).SetUint64(uint64(*val.U64)), true
+	case xdr.ScValTypeScvTimepoint:
+		return new(big.Int).SetUint64(uint64(*val.Timepoint)), true
+	case xdr.ScValTypeScvDuration:
+		return new(big.Int).SetUint64(uint64(*val.Duration)), true
+	case xdr.ScValTypeScvI128:
+		return I128ToBigInt(*val.I128), true
+	case xdr.ScValTypeScvU128:
+		return U128ToBigInt(*val.U128), true
+	case xdr.ScValTypeScvI256:
+		return I256ToBigInt(*val.I256), true
+	case xdr.ScValTypeScvU256:
+		return U256ToBigInt(*val.U256), true
+	default:
+		return nil, false
+	}
+}
+
+// BigIntToScVal encodes value as the integer ScVal type named by kind, such
+// as i128.
+func BigIntToScVal(value *big.Int, kind string) (xdr.ScVal, error) {
+	inRange := func(lo, hi *big.Int) error {
+		if value.Cmp(lo) < 0 || value.Cmp(hi) > 0 {
+			return fmt.Errorf("%s overflows %s", value, kind)
+		}
+		return nil
+	}
+
+	switch kind {
+	case "i32":
+		if err := inRange(big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)); err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvI32, xdr.Int32(value.Int64()))
+	case "u32":
+		if err := inRange(big.NewInt(0), big.NewInt(math.MaxUint32)); err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvU32, xdr.Uint32(value.Uint64()))
+	case "i64":
+		if err := inRange(big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)); err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvI64, xdr.Int64(value.Int64()))
+	case "u64", "timepoint", "duration":
+		if err := inRange(big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)); err != nil {
+			return xdr.ScVal{}, err
+		}
+		switch kind {
+		case "timepoint":
+			return xdr.NewScVal(xdr.ScValTypeScvTimepoint, xdr.TimePoint(value.Uint64()))
+		case "duration":
+			return xdr.NewScVal(xdr.ScValTypeScvDuration, xdr.Duration(value.Uint64()))
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvU64, xdr.Uint64(value.Uint64()))
+	case "i128":
+		parts, err := BigIntToI128(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvI128, parts)
+	case "u128":
+		parts, err := BigIntToU128(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvU128, parts)
+	case "i256":
+		parts, err := BigIntToI256(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvI256, parts)
+	case "u256":
+		parts, err := BigIntToU256(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvU256, parts)
+	default:
+		return xdr.ScVal{}, fmt.Errorf("%s is not an integer type", kind)
+	}
+}
+
+func pow10(decimals int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+func decodeScVal(path string, val xdr.ScVal, v reflect.Value, opts fieldOptions) error {
+	if v.Kind() == reflect.Pointer {
+		if val.Type == xdr.ScValTypeScvVoid {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeScVal(path, val, v.Elem(), opts)
+	}
+
+	if opts.kind != "" && val.Type != scValKinds[opts.kind] {
+		return typeError(path, opts.kind, val)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		if err := v.Addr().Interface().(ScValUnmarshaler).UnmarshalScVal(val); err != nil {
+			return &ScValError{Path: path, Err: err}
+		}
+		return nil
+	}
+
+	switch v.Type() {
+	case scValType:
+		v.Set(reflect.ValueOf(val))
+		return nil
+	case scAddressType:
+		address, ok := val.GetAddress()
+		if !ok {
+			return typeError(path, "address", val)
+		}
+		v.Set(reflect.ValueOf(address))
+		return nil
+	case bigIntType:
+		value, ok := ScValToBigInt(val)
+		if !ok {
+			return typeError(path, "integer", val)
+		}
+		v.Set(reflect.ValueOf(*value))
+		return nil
+	case decimalType:
+		value, ok := ScValToBigInt(val)
+		if !ok {
+			return typeError(path, "integer", val)
+		}
+		v.Set(reflect.ValueOf(Decimal{unscaled: value, scale: uint8(opts.decimals)}))
+		return nil
+	case timeType:
+		value, ok := ScValToBigInt(val)
+		if !ok || !value.IsInt64() {
+			return typeError(path, "timepoint", val)
+		}
+		v.Set(reflect.ValueOf(time.Unix(value.Int64(), 0)))
+		return nil
+	case durationType:
+		value, ok := ScValToBigInt(val)
+		if !ok || !value.IsInt64() || value.Int64() > math.MaxInt64/int64(time.Second) {
+			return typeError(path, "duration", val)
+		}
+		v.Set(reflect.ValueOf(time.Duration(value.Int64()) * time.Second))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := val.GetB()
+		if !ok {
+			return typeError(path, "bool", val)
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, ok := ScValToBigInt(val)
+		if !ok {
+			return typeError(path, "integer", val)
+		}
+		if !value.IsInt64() || v.OverflowInt(value.Int64()) {
+			return &ScValError{Path: path, Err: fmt.Errorf("%s overflows %s", value, v.Type())}
+		}
+		v.SetInt(value.Int64())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, ok := ScValToBigInt(val)
+		if !ok {
+			return typeError(path, "integer", val)
+		}
+		if value.Sign() < 0 || !value.IsUint64() || v.OverflowUint(value.Uint64()) {
+			return &ScValError{Path: path, Err: fmt.Errorf("%s overflows %s", value, v.Type())}
+		}
+		v.SetUint(value.Uint64())
+
+	case reflect.Float32, reflect.Float64:
+		value, ok := ScValToBigInt(val)
+		if !ok {
+			return typeError(path, "integer", val)
+		}
+		f := new(big.Float).SetInt(value)
+		f.Quo(f, new(big.Float).SetInt(pow10(opts.decimals)))
+		result, _ := f.Float64()
+		v.SetFloat(result)
+
+	case reflect.String:
+		s, err := decodeString(path, val, opts)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok := val.GetBytes()
+			if !ok {
+				return typeError(path, "bytes", val)
+			}
+			v.SetBytes(slices.Clone(b))
+			return nil
+		}
+
+		vec, err := scValVec(path, val)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), len(vec), len(vec))
+		for idx, item := range vec {
+			if err := decodeScVal(fmt.Sprintf("%s[%d]", path, idx), item, slice.Index(idx), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, ok := val.GetBytes()
+			if !ok {
+				return typeError(path, "bytes", val)
+			}
+			if len(b) != v.Len() {
+				return &ScValError{Path: path, Err: fmt.Errorf("expected %d bytes, got %d", v.Len(), len(b))}
+			}
+			reflect.Copy(v, reflect.ValueOf([]byte(b)))
+			return nil
+		}
+
+		vec, err := scValVec(path, val)
+		if err != nil {
+			return err
+		}
+		if len(vec) != v.Len() {
+			return &ScValError{Path: path, Err: fmt.Errorf("expected %d items, got %d", v.Len(), len(vec))}
+		}
+		for idx, item := range vec {
+			if err := decodeScVal(fmt.Sprintf("%s[%d]", path, idx), item, v.Index(idx), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		scMap, err := scValMap(path, val)
+		if err != nil {
+			return err
+		}
+		result := reflect.MakeMapWithSize(v.Type(), len(scMap))
+		for idx, entry := range scMap {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := decodeScVal(fmt.Sprintf("%s{key %d}", path, idx), entry.Key, key, fieldOptions{}); err != nil {
+				return err
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeScVal(fmt.Sprintf("%s[%v]", path, key.Interface()), entry.Val, item, fieldOptions{}); err != nil {
+				return err
+			}
+			result.SetMapIndex(key, item)
+		}
+		v.Set(result)
+
+	case reflect.Struct:
+		return decodeStruct(path, val, v, opts)
+
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return &ScValError{Path: path, Err: fmt.Errorf("cannot decode into %s", v.Type())}
+		}
+		v.Set(reflect.ValueOf(val))
+
+	default:
+		return &ScValError{Path: path, Err: fmt.Errorf("cannot decode into %s", v.Type())}
+	}
+
+	return nil
+}
+
+func decodeString(path string, val xdr.ScVal, opts fieldOptions) (string, error) {
+	if opts.enum {
+		vec, ok := val.GetVec()
+		if !ok || vec == nil || len(*vec) != 1 || (*vec)[0].Type != xdr.ScValTypeScvSymbol {
+			return "", typeError(path, "enum variant", val)
+		}
+		return string(*(*vec)[0].Sym), nil
+	}
+
+	switch val.Type {
+	case xdr.ScValTypeScvSymbol:
+		return string(*val.Sym), nil
+	case xdr.ScValTypeScvString:
+		return string(*val.Str), nil
+	case xdr.ScValTypeScvAddress:
+		address, err := EncodeScAddress(*val.Address)
+		if err != nil {
+			return "", &ScValError{Path: path, Err: err}
+		}
+		return address, nil
+	default:
+		return "", typeError(path, "symbol, string or address", val)
+	}
+}
+
+func decodeStruct(path string, val xdr.ScVal, v reflect.Value, opts fieldOptions) error {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return &ScValError{Path: path, Err: err}
+	}
+
+	if opts.tuple {
+		vec, err := scValVec(path, val)
+		if err != nil {
+			return err
+		}
+		if len(vec) != len(fields) {
+			return &ScValError{Path: path, Err: fmt.Errorf("expected %d items, got %d", len(fields), len(vec))}
+		}
+		for idx, field := range fields {
+			fieldPath := fmt.Sprintf("%s[%d]", path, idx)
+			if err := decodeScVal(fieldPath, vec[idx], v.Field(field.index), field.opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scMap, err := scValMap(path, val)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]structField, len(fields))
+	for _, field := range fields {
+		byName[field.name] = field
+	}
+
+	found := make(map[string]bool, len(fields))
+	for idx, entry := range scMap {
+		var name string
+		switch entry.Key.Type {
+		case xdr.ScValTypeScvSymbol:
+			name = string(*entry.Key.Sym)
+		case xdr.ScValTypeScvString:
+			name = string(*entry.Key.Str)
+		default:
+			return typeError(fmt.Sprintf("%s{key %d}", path, idx), "symbol", entry.Key)
+		}
+
+		field, ok := byName[name]
+		if !ok {
+			continue
+		}
+		found[name] = true
+		if err := decodeScVal(path+"."+name, entry.Val, v.Field(field.index), field.opts); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range fields {
+		if field.opts.required && !found[field.name] {
+			return &ScValError{Path: path + "." + field.name, Err: errors.New("missing required field")}
+		}
+	}
+
+	return nil
+}
+
+func scValVec(path string, val xdr.ScVal) (xdr.ScVec, error) {
+	vec, ok := val.GetVec()
+	if !ok || vec == nil {
+		return nil, typeError(path, "vec", val)
+	}
+	return *vec, nil
+}
+
+func scValMap(path string, val xdr.ScVal) (xdr.ScMap, error) {
+	scMap, ok := val.GetMap()
+	if !ok || scMap == nil {
+		return nil, typeError(path, "map", val)
+	}
+	return *scMap, nil
+}
+
+func encodeScVal(path string, v reflect.Value, opts fieldOptions) (xdr.ScVal, error) {
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return xdr.ScVal{Type: xdr.ScValTypeScvVoid}, nil
+	}
+
+	if v.Type().Implements(marshalerType) {
+		val, err := v.Interface().(ScValMarshaler).MarshalScVal()
+		if err != nil {
+			return xdr.ScVal{}, &ScValError{Path: path, Err: err}
+		}
+		return val, nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+		val, err := v.Addr().Interface().(ScValMarshaler).MarshalScVal()
+		if err != nil {
+			return xdr.ScVal{}, &ScValError{Path: path, Err: err}
+		}
+		return val, nil
+	}
+
+	wrap := func(val xdr.ScVal, err error) (xdr.ScVal, error) {
+		if err != nil {
+			return xdr.ScVal{}, &ScValError{Path: path, Err: err}
+		}
+		return val, nil
+	}
+	kind := func(fallback string) string {
+		if opts.kind != "" {
+			return opts.kind
+		}
+		return fallback
+	}
+
+	switch v.Type() {
+	case scValType:
+		return v.Interface().(xdr.ScVal), nil
+	case scAddressType:
+		return wrap(xdr.NewScVal(xdr.ScValTypeScvAddress, v.Interface().(xdr.ScAddress)))
+	case bigIntType:
+		value := new(big.Int)
+		if v.CanAddr() {
+			value.Set(v.Addr().Interface().(*big.Int))
+		} else {
+			copied := v.Interface().(big.Int)
+			value.Set(&copied)
+		}
+		return wrap(BigIntToScVal(value, kind("i128")))
+	case decimalType:
+		d := v.Interface().(Decimal)
+		if d.scale != uint8(opts.decimals) {
+			rescaled := d.Rescale(uint8(opts.decimals), RoundFloor)
+			if rescaled.Cmp(d) != 0 {
+				return xdr.ScVal{}, &ScValError{Path: path, Err: fmt.Errorf("%s has more than %d decimals", d, opts.decimals)}
+			}
+			d = rescaled
+		}
+		return wrap(BigIntToScVal(d.value(), kind("i128")))
+	case timeType:
+		return wrap(BigIntToScVal(big.NewInt(v.Interface().(time.Time).Unix()), kind("timepoint")))
+	case durationType:
+		seconds := int64(v.Interface().(time.Duration) / time.Second)
+		return wrap(BigIntToScVal(big.NewInt(seconds), kind("duration")))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return encodeScVal(path, v.Elem(), opts)
+
+	case reflect.Bool:
+		return wrap(xdr.NewScVal(xdr.ScValTypeScvBool, v.Bool()))
+
+	case reflect.Int, reflect.Int64:
+		return wrap(BigIntToScVal(big.NewInt(v.Int()), kind("i64")))
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return wrap(BigIntToScVal(big.NewInt(v.Int()), kind("i32")))
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return wrap(BigIntToScVal(new(big.Int).SetUint64(v.Uint()), kind("u64")))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return wrap(BigIntToScVal(new(big.Int).SetUint64(v.Uint()), kind("u32")))
+
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return xdr.ScVal{}, &ScValError{Path: path, Err: fmt.Errorf("cannot encode %v", f)}
+		}
+		scaled := new(big.Float).SetFloat64(f)
+		scaled.Mul(scaled, new(big.Float).SetInt(pow10(opts.decimals)))
+		if scaled.Sign() >= 0 {
+			scaled.Add(scaled, big.NewFloat(0.5))
+		} else {
+			scaled.Sub(scaled, big.NewFloat(0.5))
+		}
+		value, _ := scaled.Int(nil)
+		return wrap(BigIntToScVal(value, kind("i128")))
+
+	case reflect.String:
+		s := v.String()
+		if opts.enum {
+			sym := xdr.ScSymbol(s)
+			vec := &xdr.ScVec{{Type: xdr.ScValTypeScvSymbol, Sym: &sym}}
+			return wrap(xdr.NewScVal(xdr.ScValTypeScvVec, vec))
+		}
+		switch kind("string") {
+		case "symbol":
+			return wrap(xdr.NewScVal(xdr.ScValTypeScvSymbol, xdr.ScSymbol(s)))
+		case "address":
+			address, err := ParseScAddress(s)
+			if err != nil {
+				return xdr.ScVal{}, &ScValError{Path: path, Err: err}
+			}
+			return wrap(xdr.NewScVal(xdr.ScValTypeScvAddress, address))
+		case "string":
+			return wrap(xdr.NewScVal(xdr.ScValTypeScvString, xdr.ScString(s)))
+		default:
+			return xdr.ScVal{}, &ScValError{Path: path, Err: fmt.Errorf("cannot encode a string as %s", opts.kind)}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return wrap(xdr.NewScVal(xdr.ScValTypeScvBytes, xdr.ScBytes(b)))
+		}
+		vec := make(xdr.ScVec, v.Len())
+		for idx := range vec {
+			item, err := encodeScVal(fmt.Sprintf("%s[%d]", path, idx), v.Index(idx), fieldOptions{})
+			if err != nil {
+				return xdr.ScVal{}, err
+			}
+			vec[idx] = item
+		}
+		return wrap(xdr.NewScVal(xdr.ScValTypeScvVec, &vec))
+
+	case reflect.Map:
+		scMap := make(xdr.ScMap, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			keyPath := fmt.Sprintf("%s[%v]", path, iter.Key().Interface())
+			key, err := encodeScVal(keyPath, iter.Key(), fieldOptions{})
+			if err != nil {
+				return xdr.ScVal{}, err
+			}
+			item, err := encodeScVal(keyPath, iter.Value(), fieldOptions{})
+			if err != nil {
+				return xdr.ScVal{}, err
+			}
+			scMap = append(scMap, xdr.ScMapEntry{Key: key, Val: item})
+		}
+		SortScMap(scMap)
+		return wrap(xdr.NewScVal(xdr.ScValTypeScvMap, &scMap))
+
+	case reflect.Struct:
+		return encodeStruct(path, v, opts)
+
+	default:
+		return xdr.ScVal{}, &ScValError{Path: path, Err: fmt.Errorf("cannot encode %s", v.Type())}
+	}
+}
+
+func encodeStruct(path string, v reflect.Value, opts fieldOptions) (xdr.ScVal, error) {
+	fields, err := structFields(v.Type())
+	if err != nil {
+		return xdr.ScVal{}, &ScValError{Path: path, Err: err}
+	}
+
+	if opts.tuple {
+		vec := make(xdr.ScVec, len(fields))
+		for idx, field := range fields {
+			item, err := encodeScVal(fmt.Sprintf("%s[%d]", path, idx), v.Field(field.index), field.opts)
+			if err != nil {
+				return xdr.ScVal{}, err
+			}
+			vec[idx] = item
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvVec, &vec)
+	}
+
+	scMap := make(xdr.ScMap, len(fields))
+	for idx, field := range fields {
+		item, err := encodeScVal(path+"."+field.name, v.Field(field.index), field.opts)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		sym := xdr.ScSymbol(field.name)
+		scMap[idx] = xdr.ScMapEntry{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, Val: item}
+	}
+	SortScMap(scMap)
+	return xdr.NewScVal(xdr.ScValTypeScvMap, &scMap)
+}
+
+// SortScMap sorts a map's entries by key, the order the host requires.
+func SortScMap(scMap xdr.ScMap) {
+	slices.SortStableFunc(scMap, func(a, b xdr.ScMapEntry) int {
+		return CompareScVal(a.Key, b.Key)
+	})
+}
+
+// CompareScVal orders ScVals the way the host does: by type, then by value.
+func CompareScVal(a, b xdr.ScVal) int {
+	if a.Type != b.Type {
+		return cmp.Compare(a.Type, b.Type)
+	}
+
+	if x, ok := ScValToBigInt(a); ok {
+		y, _ := ScValToBigInt(b)
+		return x.Cmp(y)
+	}
+
+	switch a.Type {
+	case xdr.ScValTypeScvBool:
+		switch {
+		case *a.B == *b.B:
+			return 0
+		case *b.B:
+			return -1
+		default:
+			return 1
+		}
+	case xdr.ScValTypeScvVoid, xdr.ScValTypeScvLedgerKeyContractInstance:
+		return 0
+	case xdr.ScValTypeScvSymbol:
+		return strings.Compare(string(*a.Sym), string(*b.Sym))
+	case xdr.ScValTypeScvString:
+		return strings.Compare(string(*a.Str), string(*b.Str))
+	case xdr.ScValTypeScvBytes:
+		return bytes.Compare(*a.Bytes, *b.Bytes)
+	case xdr.ScValTypeScvVec:
+		x, y := derefVec(a), derefVec(b)
+		for idx := 0; idx < len(x) && idx < len(y); idx++ {
+			if c := CompareScVal(x[idx], y[idx]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(x), len(y))
+	case xdr.ScValTypeScvMap:
+		x, y := derefMap(a), derefMap(b)
+		for idx := 0; idx < len(x) && idx < len(y); idx++ {
+			if c := CompareScVal(x[idx].Key, y[idx].Key); c != 0 {
+				return c
+			}
+			if c := CompareScVal(x[idx].Val, y[idx].Val); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(x), len(y))
+	}
+
+	// Addresses, errors and the remaining types order as their XDR does,
+	// discriminant first.
+	x, errA := a.MarshalBinary()
+	y, errB := b.MarshalBinary()
+	if err := errors.Join(errA, errB); err != nil {
+		return 0
+	}
+	return bytes.Compare(x, y)
+}
+
+func derefVec(val xdr.ScVal) xdr.ScVec {
+	if val.Vec == nil || *val.Vec == nil {
+		return nil
+	}
+	return **val.Vec
+}
+
+func derefMap(val xdr.ScVal) xdr.ScMap {
+	if val.Map == nil || *val.Map == nil {
+		return nil
+	}
+	return **val.Map
+}
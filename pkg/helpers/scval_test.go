@@ -0,0 +1,187 @@
+package helpers
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testQ4W struct {
+	Amount     float64   `soroban:"amount,i128,decimals=7"`
+	Expiration time.Time `soroban:"exp,u64"`
+}
+
+type testReserve struct {
+	Asset    string   `soroban:",address"`
+	Kind     string   `soroban:",enum"`
+	Pair     testPair `soroban:",tuple"`
+	Supply   *big.Int
+	Cap      *big.Int `soroban:",u128"`
+	Note     *string
+	Q4W      []testQ4W `soroban:"q4w"`
+	Balances map[string]int64
+	Hash     [4]byte
+	Ignored  string `soroban:"-"`
+}
+
+type testPair struct {
+	Left  uint32
+	Right bool
+}
+
+func TestScValRoundTrip(t *testing.T) {
+	reserve := testReserve{
+		Asset:  keypair.MustRandom().Address(),
+		Kind:   "Collateral",
+		Pair:   testPair{Left: 3, Right: true},
+		Supply: big.NewInt(-12345),
+		Cap:    new(big.Int).Lsh(big.NewInt(1), 100),
+		Q4W: []testQ4W{
+			{Amount: 1.5, Expiration: time.Unix(1700000000, 0)},
+		},
+		Balances: map[string]int64{"b": 2, "a": 1},
+		Hash:     [4]byte{1, 2, 3, 4},
+		Ignored:  "skipped",
+	}
+
+	val, err := MarshalScVal(reserve)
+	require.NoError(t, err)
+
+	scMap := derefMap(val)
+	keys := make([]string, len(scMap))
+	for idx, entry := range scMap {
+		keys[idx] = string(entry.Key.MustSym())
+	}
+	assert.Equal(t, []string{"asset", "balances", "cap", "hash", "kind", "note", "pair", "q4w", "supply"}, keys)
+
+	balances := derefMap(scMap[1].Val)
+	assert.Equal(t, "a", string(balances[0].Key.MustStr()))
+
+	var decoded testReserve
+	require.NoError(t, UnmarshalScVal(val, &decoded))
+	assert.Empty(t, decoded.Ignored)
+	assert.Equal(t, reserve.Asset, decoded.Asset)
+	assert.Equal(t, reserve.Kind, decoded.Kind)
+	assert.Equal(t, reserve.Pair, decoded.Pair)
+	assert.Equal(t, 0, reserve.Supply.Cmp(decoded.Supply))
+	assert.Equal(t, 0, reserve.Cap.Cmp(decoded.Cap))
+	assert.Nil(t, decoded.Note)
+	assert.Equal(t, reserve.Q4W, decoded.Q4W)
+	assert.Equal(t, reserve.Balances, decoded.Balances)
+	assert.Equal(t, reserve.Hash, decoded.Hash)
+}
+
+func TestUnmarshalScValErrorPath(t *testing.T) {
+	val, err := MarshalScVal(struct {
+		Q4W []struct {
+			Exp int64 `soroban:"exp,i128"`
+		} `soroban:"q4w"`
+	}{
+		Q4W: []struct {
+			Exp int64 `soroban:"exp,i128"`
+		}{{Exp: 1}, {Exp: 2}},
+	})
+	require.NoError(t, err)
+
+	var out struct {
+		Q4W []testQ4W `soroban:"q4w"`
+	}
+	err = UnmarshalScVal(val, &out)
+
+	var scValErr *ScValError
+	require.ErrorAs(t, err, &scValErr)
+	assert.Equal(t, "$.q4w[0].exp", scValErr.Path)
+	assert.EqualError(t, err, "$.q4w[0].exp: expected u64, got i128")
+
+	var small struct {
+		Value int8 `soroban:"value"`
+	}
+	val, err = MarshalScVal(map[string]int64{"value": 300})
+	require.NoError(t, err)
+	assert.EqualError(t, UnmarshalScVal(val, &small), "$.value: 300 overflows int8")
+}
+
+func TestMarshalScValNilCollections(t *testing.T) {
+	val, err := MarshalScVal(struct {
+		Items   []uint32          `soroban:"items"`
+		Amounts map[string]uint32 `soroban:"amounts"`
+		Note    *string           `soroban:"note"`
+	}{})
+	require.NoError(t, err)
+
+	scMap := derefMap(val)
+	require.Len(t, scMap, 3)
+	assert.Equal(t, xdr.ScValTypeScvMap, scMap[0].Val.Type)
+	assert.Empty(t, derefMap(scMap[0].Val))
+	assert.Equal(t, xdr.ScValTypeScvVec, scMap[1].Val.Type)
+	assert.Empty(t, derefVec(scMap[1].Val))
+	assert.Equal(t, xdr.ScValTypeScvVoid, scMap[2].Val.Type)
+
+	// The host rejects a void where it expects a vec argument.
+	val, err = MarshalScVal([]xdr.ScVal(nil))
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ScValTypeScvVec, val.Type)
+}
+
+func TestUnmarshalScValRequiredFields(t *testing.T) {
+	val, err := MarshalScVal(map[string]uint32{"a": 1})
+	require.NoError(t, err)
+
+	var optional struct {
+		A uint32 `soroban:"a"`
+		B uint32 `soroban:"b"`
+	}
+	require.NoError(t, UnmarshalScVal(val, &optional))
+	assert.Equal(t, uint32(1), optional.A)
+	assert.Zero(t, optional.B)
+
+	var required struct {
+		A uint32 `soroban:"a,required"`
+		B uint32 `soroban:"b,required"`
+	}
+	assert.EqualError(t, UnmarshalScVal(val, &required), "$.b: missing required field")
+}
+
+func TestScValInvalidDecimals(t *testing.T) {
+	val, err := MarshalScVal(int64(1))
+	require.NoError(t, err)
+
+	var out struct {
+		Amount Decimal `soroban:"amount,i128,decimals=256"`
+	}
+	assert.ErrorContains(t, UnmarshalScVal(val, &out), `invalid option "decimals=256"`)
+	_, err = MarshalScVal(out)
+	assert.ErrorContains(t, err, `invalid option "decimals=256"`)
+}
+
+func TestCompareScVal(t *testing.T) {
+	vals := []xdr.ScVal{
+		mustMarshal(t, "b"),
+		mustMarshal(t, int64(-1)),
+		mustMarshal(t, true),
+		mustMarshal(t, "a"),
+		mustMarshal(t, int64(-5)),
+	}
+	scMap := make(xdr.ScMap, len(vals))
+	for idx, val := range vals {
+		scMap[idx] = xdr.ScMapEntry{Key: val}
+	}
+	SortScMap(scMap)
+
+	assert.True(t, scMap[0].Key.MustB())
+	assert.Equal(t, xdr.Int64(-5), scMap[1].Key.MustI64())
+	assert.Equal(t, xdr.Int64(-1), scMap[2].Key.MustI64())
+	assert.Equal(t, xdr.ScString("a"), scMap[3].Key.MustStr())
+	assert.Equal(t, xdr.ScString("b"), scMap[4].Key.MustStr())
+}
+
+func mustMarshal(t *testing.T, value any) xdr.ScVal {
+	val, err := MarshalScVal(value)
+	require.NoError(t, err)
+	return val
+}
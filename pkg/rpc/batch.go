@@ -0,0 +1,209 @@
+package soroban
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+var errBatchNotSent = errors.New("batch has not been sent")
+
+// Batch queues several calls and sends them to the server in a single
+// JSON-RPC batch request. Results are read back from the handle returned when
+// each call was queued, after Send returns.
+type Batch struct {
+	client *RpcClient
+	calls  []batchCall
+}
+
+type batchCall interface {
+	spec() jrpc2.Spec
+	resolve(rsp *jrpc2.Response)
+	fail(err error)
+	failed() error
+}
+
+// BatchCall is a queued call whose typed result becomes available once the
+// batch has been sent.
+type BatchCall[T any] struct {
+	method string
+	params any
+	result T
+	err    error
+}
+
+func (b *BatchCall[T]) spec() jrpc2.Spec {
+	return jrpc2.Spec{Method: b.method, Params: b.params}
+}
+
+func (b *BatchCall[T]) resolve(rsp *jrpc2.Response) {
+	if rpcErr := rsp.Error(); rpcErr != nil {
+		b.err = rpcErr
+		return
+	}
+
+	var result T
+	if err := rsp.UnmarshalResult(&result); err != nil {
+		b.err = err
+		return
+	}
+	b.result = result
+	b.err = nil
+}
+
+func (b *BatchCall[T]) fail(err error) {
+	b.err = err
+}
+
+func (b *BatchCall[T]) failed() error {
+	return b.err
+}
+
+// Result returns the call's response, or the error the server reported for
+// this call alone.
+func (b *BatchCall[T]) Result() (T, error) {
+	if b.err != nil {
+		var zero T
+		return zero, b.err
+	}
+	return b.result, nil
+}
+
+func queue[T any](b *Batch, method string, params any) *BatchCall[T] {
+	call := &BatchCall[T]{method: method, params: params, err: errBatchNotSent}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+func (c *RpcClient) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Len returns the number of queued calls.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Send issues every queued call in one round trip. Calls that fail with a
+// retryable error are resent together, each according to the client's retry
+// policy for its method. The returned error only reports problems with the
+// batch itself; per-call failures are returned by each call's Result.
+func (b *Batch) Send(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	pending := b.calls
+	for attempt := 1; ; attempt++ {
+		e := b.client.pickEndpoint()
+		err := e.batch(ctx, pending)
+		e.recordCall(err, b.client.failureThreshold)
+
+		var retry []batchCall
+		var backoff time.Duration
+		for _, call := range pending {
+			if err != nil {
+				call.fail(err)
+			}
+			policy := b.client.retryPolicy(call.spec().Method)
+			if ClassifyError(call.failed()).Retryable() && attempt < policy.MaxAttempts {
+				retry = append(retry, call)
+				backoff = max(backoff, policy.Backoff(attempt))
+			}
+		}
+		if len(retry) == 0 {
+			return err
+		}
+		pending = retry
+
+		if sleepErr := SleepContext(ctx, backoff); sleepErr != nil {
+			return fmt.Errorf("batch retry aborted after attempt %d: %w", attempt, sleepErr)
+		}
+	}
+}
+
+func (e *endpoint) batch(ctx context.Context, calls []batchCall) error {
+	specs := make([]jrpc2.Spec, len(calls))
+	for i, call := range calls {
+		specs[i] = call.spec()
+	}
+
+	e.mx.RLock()
+	rsps, err := e.cli.Batch(ctx, specs)
+	e.mx.RUnlock()
+	if err != nil {
+		e.refreshClient()
+		return err
+	}
+	if len(rsps) != len(calls) {
+		e.refreshClient()
+		return fmt.Errorf("unexpected number of batch responses: got %d, want %d", len(rsps), len(calls))
+	}
+
+	refresh := false
+	for i, call := range calls {
+		call.resolve(rsps[i])
+		if rsps[i].Error() != nil {
+			refresh = true
+		}
+	}
+	if refresh {
+		// This is needed because of https://github.com/creachadair/jrpc2/issues/118
+		e.refreshClient()
+	}
+	return nil
+}
+
+func (b *Batch) GetEvents(request protocol.GetEventsRequest) *BatchCall[protocol.GetEventsResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.GetEventsResponse](b, protocol.GetEventsMethodName, request)
+}
+
+func (b *Batch) GetFeeStats() *BatchCall[protocol.GetFeeStatsResponse] {
+	return queue[protocol.GetFeeStatsResponse](b, protocol.GetFeeStatsMethodName, nil)
+}
+
+func (b *Batch) GetHealth() *BatchCall[protocol.GetHealthResponse] {
+	return queue[protocol.GetHealthResponse](b, protocol.GetHealthMethodName, nil)
+}
+
+func (b *Batch) GetLatestLedger() *BatchCall[protocol.GetLatestLedgerResponse] {
+	return queue[protocol.GetLatestLedgerResponse](b, protocol.GetLatestLedgerMethodName, nil)
+}
+
+func (b *Batch) GetLedgerEntries(request protocol.GetLedgerEntriesRequest) *BatchCall[protocol.GetLedgerEntriesResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.GetLedgerEntriesResponse](b, protocol.GetLedgerEntriesMethodName, request)
+}
+
+func (b *Batch) GetLedgers(request protocol.GetLedgersRequest) *BatchCall[protocol.GetLedgersResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.GetLedgersResponse](b, protocol.GetLedgersMethodName, request)
+}
+
+func (b *Batch) GetNetwork() *BatchCall[protocol.GetNetworkResponse] {
+	return queue[protocol.GetNetworkResponse](b, protocol.GetNetworkMethodName, protocol.GetNetworkRequest{})
+}
+
+func (b *Batch) GetTransaction(request protocol.GetTransactionRequest) *BatchCall[protocol.GetTransactionResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.GetTransactionResponse](b, protocol.GetTransactionMethodName, request)
+}
+
+func (b *Batch) GetTransactions(request protocol.GetTransactionsRequest) *BatchCall[protocol.GetTransactionsResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.GetTransactionsResponse](b, protocol.GetTransactionsMethodName, request)
+}
+
+func (b *Batch) GetVersionInfo() *BatchCall[protocol.GetVersionInfoResponse] {
+	return queue[protocol.GetVersionInfoResponse](b, protocol.GetVersionInfoMethodName, nil)
+}
+
+func (b *Batch) SimulateTransaction(request protocol.SimulateTransactionRequest) *BatchCall[protocol.SimulateTransactionResponse] {
+	request.Format = b.client.format(request.Format)
+	return queue[protocol.SimulateTransactionResponse](b, protocol.SimulateTransactionMethodName, request)
+}
@@ -0,0 +1,89 @@
+package soroban
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestBatchSend(t *testing.T) {
+	server := newMockRPC(t, map[string]rpcResult{
+		protocol.GetLatestLedgerMethodName:  staticResult(`{"sequence":42}`),
+		protocol.GetLedgerEntriesMethodName: staticResult(`{"entries":[],"latestLedger":42}`),
+		protocol.GetTransactionMethodName: func(rpcRequest) (string, error) {
+			return "", &jrpc2.Error{Code: jrpc2.InvalidParams, Message: "invalid hash"}
+		},
+	})
+
+	client := NewClient(server.URL, nil)
+	defer client.Close()
+
+	batch := client.NewBatch()
+	latest := batch.GetLatestLedger()
+	entries := batch.GetLedgerEntries(protocol.GetLedgerEntriesRequest{Keys: []string{"AAAA"}})
+	tx := batch.GetTransaction(protocol.GetTransactionRequest{Hash: "nope"})
+	require.Equal(t, 3, batch.Len())
+
+	_, err := latest.Result()
+	require.Error(t, err)
+
+	require.NoError(t, batch.Send(context.Background()))
+	assert.Equal(t, int32(1), server.roundTrips())
+
+	latestResult, err := latest.Result()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(42), latestResult.Sequence)
+
+	entriesResult, err := entries.Result()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(42), entriesResult.LatestLedger)
+
+	_, err = tx.Result()
+	require.Error(t, err)
+	assert.Equal(t, ErrorClassPermanent, ClassifyError(err))
+}
+
+func TestBatchSendUsesMethodRetryPolicies(t *testing.T) {
+	var latestCalls, healthCalls atomic.Int32
+	server := newMockRPC(t, map[string]rpcResult{
+		protocol.GetLatestLedgerMethodName: func(rpcRequest) (string, error) {
+			if latestCalls.Add(1) < 3 {
+				return "", &jrpc2.Error{Code: jrpc2.InternalError, Message: "db is down"}
+			}
+			return `{"sequence":42}`, nil
+		},
+		protocol.GetHealthMethodName: func(rpcRequest) (string, error) {
+			healthCalls.Add(1)
+			return "", &jrpc2.Error{Code: jrpc2.InternalError, Message: "db is down"}
+		},
+	})
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	client := NewClient(server.URL, nil,
+		WithRetryPolicy(NoRetry()),
+		WithMethodRetryPolicy(protocol.GetLatestLedgerMethodName, policy),
+	)
+	defer client.Close()
+
+	batch := client.NewBatch()
+	latest := batch.GetLatestLedger()
+	health := batch.GetHealth()
+	require.NoError(t, batch.Send(context.Background()))
+
+	latestResult, err := latest.Result()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(42), latestResult.Sequence)
+	assert.Equal(t, int32(3), latestCalls.Load())
+
+	_, err = health.Result()
+	assert.Equal(t, ErrorClassServer, ClassifyError(err))
+	assert.Equal(t, int32(1), healthCalls.Load())
+	assert.Equal(t, int32(3), server.roundTrips())
+}
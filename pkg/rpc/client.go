@@ -2,65 +2,236 @@ package soroban
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
-	"github.com/creachadair/jrpc2"
-	"github.com/creachadair/jrpc2/jhttp"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
 	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
 )
 
 type RpcClient struct {
-	url        string
-	cli        *jrpc2.Client
-	mx         sync.RWMutex // to protect cli writes in refreshes
-	httpClient *http.Client
+	endpoints []*endpoint
+
+	retry       RetryPolicy
+	methodRetry map[string]RetryPolicy
+
+	healthCheckInterval time.Duration
+	maxLedgerLag        uint32
+	failureThreshold    int
+	stopHealthChecks    context.CancelFunc
+	healthChecks        sync.WaitGroup
+
+	passphraseMx sync.Mutex
+	passphrase   string
+
+	xdrFormat string
+}
+
+type ClientOption func(*RpcClient)
+
+// WithRetryPolicy sets the retry policy used for every method without an override.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *RpcClient) {
+		c.retry = policy
+	}
+}
+
+// WithMethodRetryPolicy overrides the retry policy for a single method, e.g.
+// protocol.SendTransactionMethodName.
+func WithMethodRetryPolicy(method string, policy RetryPolicy) ClientOption {
+	return func(c *RpcClient) {
+		if c.methodRetry == nil {
+			c.methodRetry = make(map[string]RetryPolicy)
+		}
+		c.methodRetry[method] = policy
+	}
+}
+
+// WithXDRFormat sets the xdrFormat requested from methods that support it,
+// protocol.FormatBase64 or protocol.FormatJSON, unless the request sets its
+// own. JSON responses fill the *JSON fields of the response types instead of
+// the base64 ones.
+func WithXDRFormat(format string) ClientOption {
+	return func(c *RpcClient) {
+		c.xdrFormat = format
+	}
+}
+
+func NewClient(url string, httpClient *http.Client, opts ...ClientOption) *RpcClient {
+	return newClient([]string{url}, httpClient, opts...)
 }
 
-func NewClient(url string, httpClient *http.Client) *RpcClient {
-	c := &RpcClient{url: url, httpClient: httpClient}
-	c.refreshClient()
+func newClient(urls []string, httpClient *http.Client, opts ...ClientOption) *RpcClient {
+	c := &RpcClient{
+		retry:            DefaultRetryPolicy(),
+		maxLedgerLag:     DefaultMaxLedgerLag,
+		failureThreshold: DefaultFailureThreshold,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, url := range urls {
+		c.endpoints = append(c.endpoints, newEndpoint(url, httpClient))
+	}
 	return c
 }
 
 func (c *RpcClient) Close() error {
-	c.mx.RLock()
-	defer c.mx.RUnlock()
-	return c.cli.Close()
+	if c.stopHealthChecks != nil {
+		c.stopHealthChecks()
+		c.healthChecks.Wait()
+	}
+
+	var errs []error
+	for _, e := range c.endpoints {
+		errs = append(errs, e.close())
+	}
+	return errors.Join(errs...)
+}
+
+func (c *RpcClient) retryPolicy(method string) RetryPolicy {
+	if policy, ok := c.methodRetry[method]; ok {
+		return policy
+	}
+	return c.retry
 }
 
-func (c *RpcClient) refreshClient() {
-	var opts *jhttp.ChannelOptions
-	if c.httpClient != nil {
-		opts = &jhttp.ChannelOptions{
-			Client: c.httpClient,
+func (c *RpcClient) callResult(ctx context.Context, method string, params, result any) error {
+	policy := c.retryPolicy(method)
+
+	for attempt := 1; ; attempt++ {
+		err := c.callOnce(ctx, method, params, result)
+		if err == nil {
+			return nil
+		}
+
+		class := ClassifyError(err)
+		if !class.Retryable() || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		// A write that failed ambiguously may already have been applied, so
+		// only resend it once we know it did not land.
+		if method == protocol.SendTransactionMethodName && !class.Rejected() {
+			landed, lookupErr := c.sendTransactionLanded(ctx, params, result)
+			if lookupErr != nil {
+				return err
+			}
+			if landed {
+				return nil
+			}
 		}
+
+		if sleepErr := SleepContext(ctx, policy.Backoff(attempt)); sleepErr != nil {
+			return fmt.Errorf("%s retry aborted after attempt %d: %w: %w", method, attempt, sleepErr, err)
+		}
+	}
+}
+
+// callOnce makes one attempt at a call. When the endpoint fails with a
+// retryable error and the client has others, the call is sent once more to
+// the next endpoint straight away, whatever the retry policy, so a failing
+// node does not fail calls made with NoRetry.
+func (c *RpcClient) callOnce(ctx context.Context, method string, params, result any) error {
+	e := c.pickEndpoint()
+	err := e.callResult(ctx, method, params, result)
+	e.recordCall(err, c.failureThreshold)
+	if err == nil || len(c.endpoints) == 1 {
+		return err
 	}
-	ch := jhttp.NewChannel(c.url, opts)
-	cli := jrpc2.NewClient(ch, nil)
 
-	c.mx.Lock()
-	defer c.mx.Unlock()
-	if c.cli != nil {
-		c.cli.Close()
+	class := ClassifyError(err)
+	if !class.Retryable() {
+		return err
 	}
-	c.cli = cli
+	// A transaction that may have reached the first node is left to
+	// callResult, which checks whether it landed before resending.
+	if method == protocol.SendTransactionMethodName && !class.Rejected() {
+		return err
+	}
+
+	next := c.pickEndpoint(e)
+	err = next.callResult(ctx, method, params, result)
+	next.recordCall(err, c.failureThreshold)
+	return err
 }
 
-func (c *RpcClient) callResult(ctx context.Context, method string, params, result any) error {
-	c.mx.RLock()
-	err := c.cli.CallResult(ctx, method, params, result)
-	c.mx.RUnlock()
+// sendTransactionLanded looks the transaction up by hash. If the network
+// already knows it, result is filled in as a DUPLICATE submission.
+func (c *RpcClient) sendTransactionLanded(ctx context.Context, params, result any) (bool, error) {
+	request, ok := params.(protocol.SendTransactionRequest)
+	if !ok {
+		return false, fmt.Errorf("unexpected %s params type %T", protocol.SendTransactionMethodName, params)
+	}
+
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(request.Transaction, &envelope); err != nil {
+		return false, err
+	}
+
+	passphrase, err := c.networkPassphrase(ctx)
 	if err != nil {
-		// This is needed because of https://github.com/creachadair/jrpc2/issues/118
-		c.refreshClient()
+		return false, err
 	}
-	return err
+
+	hash, err := network.HashTransactionInEnvelope(envelope, passphrase)
+	if err != nil {
+		return false, err
+	}
+	txHash := hex.EncodeToString(hash[:])
+
+	tx, err := c.GetTransaction(ctx, protocol.GetTransactionRequest{Hash: txHash})
+	if err != nil {
+		return false, err
+	}
+	if tx.Status == protocol.TransactionStatusNotFound {
+		return false, nil
+	}
+
+	if response, ok := result.(*protocol.SendTransactionResponse); ok {
+		*response = protocol.SendTransactionResponse{
+			Status:                protocol.SendTransactionStatusDuplicate,
+			Hash:                  txHash,
+			LatestLedger:          tx.LatestLedger,
+			LatestLedgerCloseTime: tx.LatestLedgerCloseTime,
+		}
+	}
+	return true, nil
+}
+
+func (c *RpcClient) networkPassphrase(ctx context.Context) (string, error) {
+	c.passphraseMx.Lock()
+	defer c.passphraseMx.Unlock()
+	if c.passphrase != "" {
+		return c.passphrase, nil
+	}
+
+	resp, err := c.GetNetwork(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.passphrase = resp.Passphrase
+	return c.passphrase, nil
+}
+
+// format returns the xdrFormat to send for a request that asked for
+// requested.
+func (c *RpcClient) format(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return c.xdrFormat
 }
 
 func (c *RpcClient) GetEvents(ctx context.Context,
 	request protocol.GetEventsRequest,
 ) (protocol.GetEventsResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.GetEventsResponse
 	err := c.callResult(ctx, protocol.GetEventsMethodName, request, &result)
 	if err != nil {
@@ -99,6 +270,7 @@ func (c *RpcClient) GetLatestLedger(ctx context.Context) (protocol.GetLatestLedg
 func (c *RpcClient) GetLedgerEntries(ctx context.Context,
 	request protocol.GetLedgerEntriesRequest,
 ) (protocol.GetLedgerEntriesResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.GetLedgerEntriesResponse
 	err := c.callResult(ctx, protocol.GetLedgerEntriesMethodName, request, &result)
 	if err != nil {
@@ -110,6 +282,7 @@ func (c *RpcClient) GetLedgerEntries(ctx context.Context,
 func (c *RpcClient) GetLedgers(ctx context.Context,
 	request protocol.GetLedgersRequest,
 ) (protocol.GetLedgersResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.GetLedgersResponse
 	err := c.callResult(ctx, protocol.GetLedgersMethodName, request, &result)
 	if err != nil {
@@ -133,6 +306,7 @@ func (c *RpcClient) GetNetwork(ctx context.Context,
 func (c *RpcClient) GetTransaction(ctx context.Context,
 	request protocol.GetTransactionRequest,
 ) (protocol.GetTransactionResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.GetTransactionResponse
 	err := c.callResult(ctx, protocol.GetTransactionMethodName, request, &result)
 	if err != nil {
@@ -144,6 +318,7 @@ func (c *RpcClient) GetTransaction(ctx context.Context,
 func (c *RpcClient) GetTransactions(ctx context.Context,
 	request protocol.GetTransactionsRequest,
 ) (protocol.GetTransactionsResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.GetTransactionsResponse
 	err := c.callResult(ctx, protocol.GetTransactionsMethodName, request, &result)
 	if err != nil {
@@ -164,6 +339,7 @@ func (c *RpcClient) GetVersionInfo(ctx context.Context) (protocol.GetVersionInfo
 func (c *RpcClient) SendTransaction(ctx context.Context,
 	request protocol.SendTransactionRequest,
 ) (protocol.SendTransactionResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.SendTransactionResponse
 	err := c.callResult(ctx, protocol.SendTransactionMethodName, request, &result)
 	if err != nil {
@@ -175,6 +351,7 @@ func (c *RpcClient) SendTransaction(ctx context.Context,
 func (c *RpcClient) SimulateTransaction(ctx context.Context,
 	request protocol.SimulateTransactionRequest,
 ) (protocol.SimulateTransactionResponse, error) {
+	request.Format = c.format(request.Format)
 	var result protocol.SimulateTransactionResponse
 	err := c.callResult(ctx, protocol.SimulateTransactionMethodName, request, &result)
 	if err != nil {
@@ -0,0 +1,242 @@
+package soroban
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/xdrjson"
+)
+
+// Ledger is a getLedgers entry with its header and close meta decoded.
+type Ledger struct {
+	Sequence  uint32
+	Hash      string
+	CloseTime int64
+	Header    xdr.LedgerHeaderHistoryEntry
+	Meta      xdr.LedgerCloseMeta
+	// Info is the raw entry the ledger was decoded from.
+	Info protocol.LedgerInfo
+}
+
+// DecodeLedger decodes the XDR fields of a getLedgers entry, fetched in
+// either format.
+func DecodeLedger(info protocol.LedgerInfo) (Ledger, error) {
+	ledger := Ledger{
+		Sequence:  info.Sequence,
+		Hash:      info.Hash,
+		CloseTime: info.LedgerCloseTime,
+		Info:      info,
+	}
+
+	if err := xdrjson.Decode(info.LedgerHeader, info.LedgerHeaderJSON, &ledger.Header); err != nil {
+		return Ledger{}, fmt.Errorf("error decoding header of ledger %d: %w", info.Sequence, err)
+	}
+	if err := xdrjson.Decode(info.LedgerMetadata, info.LedgerMetadataJSON, &ledger.Meta); err != nil {
+		return Ledger{}, fmt.Errorf("error decoding meta of ledger %d: %w", info.Sequence, err)
+	}
+
+	return ledger, nil
+}
+
+// Transaction is a getTransactions entry with its envelope, result and meta
+// decoded.
+type Transaction struct {
+	Hash             string
+	Status           string
+	Ledger           uint32
+	LedgerCloseTime  int64
+	ApplicationOrder int32
+	FeeBump          bool
+	Envelope         xdr.TransactionEnvelope
+	Result           xdr.TransactionResult
+	Meta             xdr.TransactionMeta
+	// Info is the raw entry the transaction was decoded from.
+	Info protocol.TransactionInfo
+}
+
+// DecodeTransaction decodes the XDR fields of a getTransactions entry,
+// fetched in either format.
+func DecodeTransaction(info protocol.TransactionInfo) (Transaction, error) {
+	tx := Transaction{
+		Hash:             info.TransactionHash,
+		Status:           info.Status,
+		Ledger:           info.Ledger,
+		LedgerCloseTime:  info.LedgerCloseTime,
+		ApplicationOrder: info.ApplicationOrder,
+		FeeBump:          info.FeeBump,
+		Info:             info,
+	}
+
+	if err := xdrjson.Decode(info.EnvelopeXDR, info.EnvelopeJSON, &tx.Envelope); err != nil {
+		return Transaction{}, fmt.Errorf("error decoding envelope of transaction %s: %w", info.TransactionHash, err)
+	}
+	if err := xdrjson.Decode(info.ResultXDR, info.ResultJSON, &tx.Result); err != nil {
+		return Transaction{}, fmt.Errorf("error decoding result of transaction %s: %w", info.TransactionHash, err)
+	}
+	if err := xdrjson.Decode(info.ResultMetaXDR, info.ResultMetaJSON, &tx.Meta); err != nil {
+		return Transaction{}, fmt.Errorf("error decoding meta of transaction %s: %w", info.TransactionHash, err)
+	}
+
+	return tx, nil
+}
+
+func (l Ledger) ProtocolVersion() uint32 {
+	return uint32(l.Header.Header.LedgerVersion)
+}
+
+// TransactionCount returns how many transactions the ledger applied.
+func (l Ledger) TransactionCount() int {
+	return l.Meta.CountTransactions()
+}
+
+// InvokedContract describes the contract call made by an InvokeHostFunction
+// operation.
+type InvokedContract struct {
+	Contract xdr.ScAddress
+	// ContractID is the contract's strkey.
+	ContractID string
+	Function   xdr.ScSymbol
+	Args       []xdr.ScVal
+}
+
+// TransactionFees breaks down what a transaction was charged, in stroops.
+// The resource fee components are only set for Soroban transactions.
+type TransactionFees struct {
+	Charged                  int64
+	NonRefundableResourceFee int64
+	RefundableResourceFee    int64
+	RentFee                  int64
+}
+
+func (t Transaction) Successful() bool {
+	return t.Status == protocol.TransactionStatusSuccess
+}
+
+// Operations returns the transaction's operations, taken from the inner
+// transaction for fee bumps.
+func (t Transaction) Operations() []xdr.Operation {
+	return t.Envelope.Operations()
+}
+
+// InvokedContract returns the contract call made by the transaction, and
+// false if it does not invoke a contract function.
+func (t Transaction) InvokedContract() (InvokedContract, bool, error) {
+	for _, op := range t.Operations() {
+		invokeOp, ok := op.Body.GetInvokeHostFunctionOp()
+		if !ok {
+			continue
+		}
+		args, ok := invokeOp.HostFunction.GetInvokeContract()
+		if !ok {
+			continue
+		}
+
+		contractID, err := args.ContractAddress.String()
+		if err != nil {
+			return InvokedContract{}, false, fmt.Errorf("error encoding contract address of transaction %s: %w", t.Hash, err)
+		}
+		return InvokedContract{
+			Contract:   args.ContractAddress,
+			ContractID: contractID,
+			Function:   args.FunctionName,
+			Args:       args.Args,
+		}, true, nil
+	}
+	return InvokedContract{}, false, nil
+}
+
+// ReturnValue returns the value returned by the invoked contract function,
+// or nil if the transaction did not return one.
+func (t Transaction) ReturnValue() (*xdr.ScVal, error) {
+	return ReturnValue(t.Meta)
+}
+
+// ContractEvents returns the contract events emitted by each operation,
+// preferring the per-operation events in the response over the meta.
+func (t Transaction) ContractEvents() ([][]xdr.ContractEvent, error) {
+	if eventsXdr := t.Info.Events.ContractEventsXDR; len(eventsXdr) > 0 {
+		events := make([][]xdr.ContractEvent, len(eventsXdr))
+		for opIdx, opEventsXdr := range eventsXdr {
+			events[opIdx] = make([]xdr.ContractEvent, len(opEventsXdr))
+			for idx, eventXdr := range opEventsXdr {
+				err := xdr.SafeUnmarshalBase64(eventXdr, &events[opIdx][idx])
+				if err != nil {
+					return nil, fmt.Errorf("error decoding event %d of operation %d: %w", idx, opIdx, err)
+				}
+			}
+		}
+		return events, nil
+	}
+	if eventsJSON := t.Info.Events.ContractEventsJSON; len(eventsJSON) > 0 {
+		events := make([][]xdr.ContractEvent, len(eventsJSON))
+		for opIdx, opEventsJSON := range eventsJSON {
+			events[opIdx] = make([]xdr.ContractEvent, len(opEventsJSON))
+			for idx, eventJSON := range opEventsJSON {
+				event, err := xdrjson.UnmarshalContractEvent(eventJSON)
+				if err != nil {
+					return nil, fmt.Errorf("error decoding event %d of operation %d: %w", idx, opIdx, err)
+				}
+				events[opIdx][idx] = event
+			}
+		}
+		return events, nil
+	}
+
+	events := make([][]xdr.ContractEvent, len(t.Operations()))
+	for opIdx := range events {
+		opEvents, err := t.Meta.GetContractEventsForOperation(uint32(opIdx))
+		if err != nil {
+			return nil, err
+		}
+		events[opIdx] = opEvents
+	}
+	return events, nil
+}
+
+func (t Transaction) Fees() TransactionFees {
+	fees := TransactionFees{Charged: int64(t.Result.FeeCharged)}
+
+	var ext xdr.SorobanTransactionMetaExt
+	switch t.Meta.V {
+	case 3:
+		if sorobanMeta := t.Meta.MustV3().SorobanMeta; sorobanMeta != nil {
+			ext = sorobanMeta.Ext
+		}
+	case 4:
+		if sorobanMeta := t.Meta.MustV4().SorobanMeta; sorobanMeta != nil {
+			ext = sorobanMeta.Ext
+		}
+	}
+
+	if feeExt, ok := ext.GetV1(); ok {
+		fees.NonRefundableResourceFee = int64(feeExt.TotalNonRefundableResourceFeeCharged)
+		fees.RefundableResourceFee = int64(feeExt.TotalRefundableResourceFeeCharged)
+		fees.RentFee = int64(feeExt.RentFeeCharged)
+	}
+	return fees
+}
+
+// ReturnValue returns the value returned by the contract function a
+// transaction invoked, or nil if it returned none.
+func ReturnValue(meta xdr.TransactionMeta) (*xdr.ScVal, error) {
+	switch meta.V {
+	case 0, 1, 2:
+		return nil, nil
+	case 3:
+		sorobanMeta := meta.MustV3().SorobanMeta
+		if sorobanMeta == nil {
+			return nil, nil
+		}
+		returnValue := sorobanMeta.ReturnValue
+		return &returnValue, nil
+	case 4:
+		sorobanMeta := meta.MustV4().SorobanMeta
+		if sorobanMeta == nil {
+			return nil, nil
+		}
+		return sorobanMeta.ReturnValue, nil
+	default:
+		return nil, fmt.Errorf("unsupported TransactionMeta version: %v", meta.V)
+	}
+}
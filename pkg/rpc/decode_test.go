@@ -0,0 +1,175 @@
+package soroban
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestDecodeTransaction(t *testing.T) {
+	contractID := xdr.ContractId{1, 2, 3}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID}
+	arg := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: new(xdr.Uint32)}
+	*arg.U32 = 7
+
+	source := xdr.MustMuxedAddress(keypair.MustRandom().Address())
+	envelope := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx: xdr.Transaction{
+				SourceAccount: source,
+				Operations: []xdr.Operation{{
+					Body: xdr.OperationBody{
+						Type: xdr.OperationTypeInvokeHostFunction,
+						InvokeHostFunctionOp: &xdr.InvokeHostFunctionOp{
+							HostFunction: xdr.HostFunction{
+								Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+								InvokeContract: &xdr.InvokeContractArgs{
+									ContractAddress: contract,
+									FunctionName:    "submit",
+									Args:            []xdr.ScVal{arg},
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	result := xdr.TransactionResult{
+		FeeCharged: 1234,
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &[]xdr.OperationResult{}},
+	}
+
+	returnValue := xdr.ScVal{Type: xdr.ScValTypeScvBool, B: new(bool)}
+	event := xdr.ContractEvent{
+		ContractId: &contractID,
+		Type:       xdr.ContractEventTypeContract,
+		Body: xdr.ContractEventBody{
+			V:  0,
+			V0: &xdr.ContractEventV0{Data: xdr.ScVal{Type: xdr.ScValTypeScvVoid}},
+		},
+	}
+	meta := xdr.TransactionMeta{
+		V: 4,
+		V4: &xdr.TransactionMetaV4{
+			Operations: []xdr.OperationMetaV2{{Events: []xdr.ContractEvent{event}}},
+			SorobanMeta: &xdr.SorobanTransactionMetaV2{
+				Ext: xdr.SorobanTransactionMetaExt{
+					V: 1,
+					V1: &xdr.SorobanTransactionMetaExtV1{
+						TotalNonRefundableResourceFeeCharged: 100,
+						TotalRefundableResourceFeeCharged:    20,
+						RentFeeCharged:                       3,
+					},
+				},
+				ReturnValue: &returnValue,
+			},
+		},
+	}
+
+	envelopeXdr, err := xdr.MarshalBase64(envelope)
+	require.NoError(t, err)
+	resultXdr, err := xdr.MarshalBase64(result)
+	require.NoError(t, err)
+	metaXdr, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	tx, err := DecodeTransaction(protocol.TransactionInfo{
+		TransactionDetails: protocol.TransactionDetails{
+			Status:          protocol.TransactionStatusSuccess,
+			TransactionHash: "abcd",
+			EnvelopeXDR:     envelopeXdr,
+			ResultXDR:       resultXdr,
+			ResultMetaXDR:   metaXdr,
+			Ledger:          42,
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, tx.Successful())
+	assert.Equal(t, uint32(42), tx.Ledger)
+
+	invoked, ok, err := tx.InvokedContract()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, strkey.MustEncode(strkey.VersionByteContract, contractID[:]), invoked.ContractID)
+	assert.Equal(t, xdr.ScSymbol("submit"), invoked.Function)
+	require.Len(t, invoked.Args, 1)
+	assert.True(t, invoked.Args[0].Equals(arg))
+
+	value, err := tx.ReturnValue()
+	require.NoError(t, err)
+	require.NotNil(t, value)
+	assert.True(t, value.Equals(returnValue))
+
+	events, err := tx.ContractEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Len(t, events[0], 1)
+	assert.Equal(t, contractID, *events[0][0].ContractId)
+
+	assert.Equal(t, TransactionFees{
+		Charged:                  1234,
+		NonRefundableResourceFee: 100,
+		RefundableResourceFee:    20,
+		RentFee:                  3,
+	}, tx.Fees())
+}
+
+func TestDecodeTransactionJSON(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	contract := strkey.MustEncode(strkey.VersionByteContract, []byte{1, 2, 3, 31: 0})
+	event := `{"ext":"v0","contract_id":"` + contract + `","type_":"contract","body":{"v0":{"topics":[],"data":"void"}}}`
+
+	tx, err := DecodeTransaction(protocol.TransactionInfo{
+		TransactionDetails: protocol.TransactionDetails{
+			Status:          protocol.TransactionStatusSuccess,
+			TransactionHash: "abcd",
+			EnvelopeJSON: json.RawMessage(`{"tx":{"tx":{"source_account":"` + source + `","fee":100,"seq_num":"1",
+				"cond":"none","memo":"none","operations":[{"source_account":null,"body":{"invoke_host_function":{
+				"host_function":{"invoke_contract":{"contract_address":"` + contract + `","function_name":"submit","args":[{"u32":7}]}},
+				"auth":[]}}}],"ext":"v0"},"signatures":[]}}`),
+			ResultJSON: json.RawMessage(`{"fee_charged":"1234","result":{"tx_success":[]},"ext":"v0"}`),
+			ResultMetaJSON: json.RawMessage(`{"v4":{"ext":"v0","tx_changes_before":[],"operations":[{"ext":"v0","changes":[],"events":[]}],
+				"tx_changes_after":[],"soroban_meta":{"ext":{"v1":{"ext":"v0","total_non_refundable_resource_fee_charged":"100",
+				"total_refundable_resource_fee_charged":"20","rent_fee_charged":"3"}},"return_value":{"bool":false}},
+				"events":[],"diagnostic_events":[]}}`),
+			Events: protocol.Events{ContractEventsJSON: [][]json.RawMessage{{json.RawMessage(event)}}},
+			Ledger: 42,
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, tx.Successful())
+
+	invoked, ok, err := tx.InvokedContract()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, contract, invoked.ContractID)
+	assert.Equal(t, xdr.ScSymbol("submit"), invoked.Function)
+
+	value, err := tx.ReturnValue()
+	require.NoError(t, err)
+	require.NotNil(t, value)
+	assert.False(t, value.MustB())
+
+	events, err := tx.ContractEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Len(t, events[0], 1)
+	assert.Equal(t, xdr.ContractEventTypeContract, events[0][0].Type)
+
+	assert.Equal(t, TransactionFees{
+		Charged:                  1234,
+		NonRefundableResourceFee: 100,
+		RefundableResourceFee:    20,
+		RentFee:                  3,
+	}, tx.Fees())
+}
@@ -0,0 +1,258 @@
+package soroban
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/checkpoint"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+const (
+	// MaxPageLimit is the largest page Stellar-RPC serves for getLedgers and
+	// getTransactions.
+	MaxPageLimit            = 200
+	DefaultPageLimit        = 50
+	DefaultLedgerPollPeriod = 5 * time.Second
+)
+
+// IterOptions controls where a getLedgers or getTransactions iterator starts
+// and stops.
+type IterOptions struct {
+	// StartLedger is the first ledger to read. Zero starts at the oldest
+	// ledger the RPC retains.
+	StartLedger uint32
+	// EndLedger is the last ledger to read, inclusive. Zero reads to the tip.
+	EndLedger uint32
+	// Cursor resumes after a cursor returned by a previous page. It takes
+	// precedence over StartLedger.
+	Cursor string
+	// Limit is the page size, at most MaxPageLimit.
+	Limit uint
+	// Follow keeps the iterator waiting for new ledgers once it reaches the
+	// tip, until EndLedger or until the caller stops.
+	Follow bool
+	// PollInterval is how often to check for new ledgers while following.
+	PollInterval time.Duration
+	// Format is the xdrFormat to request. Empty uses the client's default.
+	Format string
+	// Checkpoint, if set, supplies the starting cursor saved under
+	// CheckpointName and receives the cursor after every page has been
+	// yielded. A saved cursor takes precedence over Cursor and StartLedger.
+	Checkpoint     checkpoint.CursorStore
+	CheckpointName string
+}
+
+// Ledgers iterates over ledgers as returned by getLedgers, following the
+// response cursors from page to page. Iteration stops at the first error,
+// which is yielded along with a zero LedgerInfo.
+func (c *RpcClient) Ledgers(ctx context.Context, opts IterOptions) iter.Seq2[protocol.LedgerInfo, error] {
+	fetch := func(ctx context.Context, startLedger uint32, pagination *protocol.LedgerPaginationOptions) (page[protocol.LedgerInfo], error) {
+		resp, err := c.GetLedgers(ctx, protocol.GetLedgersRequest{StartLedger: startLedger, Pagination: pagination, Format: opts.Format})
+		if err != nil {
+			return page[protocol.LedgerInfo]{}, err
+		}
+		return page[protocol.LedgerInfo]{
+			items:        resp.Ledgers,
+			cursor:       resp.Cursor,
+			latestLedger: resp.LatestLedger,
+			oldestLedger: resp.OldestLedger,
+		}, nil
+	}
+	ledgerOf := func(ledger protocol.LedgerInfo) uint32 { return ledger.Sequence }
+
+	return paginate(ctx, c, opts, protocol.GetLedgersMethodName, fetch, ledgerOf)
+}
+
+// Transactions iterates over transactions as returned by getTransactions,
+// following the response cursors from page to page. Iteration stops at the
+// first error, which is yielded along with a zero TransactionInfo.
+func (c *RpcClient) Transactions(ctx context.Context, opts IterOptions) iter.Seq2[protocol.TransactionInfo, error] {
+	fetch := func(ctx context.Context, startLedger uint32, pagination *protocol.LedgerPaginationOptions) (page[protocol.TransactionInfo], error) {
+		resp, err := c.GetTransactions(ctx, protocol.GetTransactionsRequest{StartLedger: startLedger, Pagination: pagination, Format: opts.Format})
+		if err != nil {
+			return page[protocol.TransactionInfo]{}, err
+		}
+		return page[protocol.TransactionInfo]{
+			items:        resp.Transactions,
+			cursor:       resp.Cursor,
+			latestLedger: resp.LatestLedger,
+			oldestLedger: resp.OldestLedger,
+		}, nil
+	}
+	ledgerOf := func(tx protocol.TransactionInfo) uint32 { return tx.Ledger }
+
+	return paginate(ctx, c, opts, protocol.GetTransactionsMethodName, fetch, ledgerOf)
+}
+
+// DecodedLedgers is Ledgers with every ledger's XDR decoded.
+func (c *RpcClient) DecodedLedgers(ctx context.Context, opts IterOptions) iter.Seq2[Ledger, error] {
+	return decodeSeq(c.Ledgers(ctx, opts), DecodeLedger)
+}
+
+// DecodedTransactions is Transactions with every transaction's XDR decoded.
+func (c *RpcClient) DecodedTransactions(ctx context.Context, opts IterOptions) iter.Seq2[Transaction, error] {
+	return decodeSeq(c.Transactions(ctx, opts), DecodeTransaction)
+}
+
+type page[T any] struct {
+	items        []T
+	cursor       string
+	latestLedger uint32
+	oldestLedger uint32
+}
+
+type pageFetcher[T any] func(
+	ctx context.Context,
+	startLedger uint32,
+	pagination *protocol.LedgerPaginationOptions,
+) (page[T], error)
+
+func paginate[T any](
+	ctx context.Context,
+	c *RpcClient,
+	opts IterOptions,
+	method string,
+	fetch pageFetcher[T],
+	ledgerOf func(T) uint32,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		fail := func(err error) {
+			yield(zero, fmt.Errorf("error iterating %s: %w", method, err))
+		}
+
+		limit := opts.Limit
+		if limit == 0 {
+			limit = DefaultPageLimit
+		}
+		interval := opts.PollInterval
+		if interval <= 0 {
+			interval = DefaultLedgerPollPeriod
+		}
+
+		cursor := opts.Cursor
+		if opts.Checkpoint != nil {
+			saved, ok, err := opts.Checkpoint.Load(ctx, opts.CheckpointName)
+			if err != nil {
+				fail(err)
+				return
+			}
+			if ok {
+				cursor = saved
+			}
+		}
+
+		health, err := c.GetHealth(ctx)
+		if err != nil {
+			fail(err)
+			return
+		}
+		ledgerRange := protocol.LedgerSeqRange{FirstLedger: health.OldestLedger, LastLedger: health.LatestLedger}
+
+		var startLedger uint32
+		if cursor == "" {
+			startLedger = opts.StartLedger
+			if startLedger == 0 {
+				startLedger = health.OldestLedger
+			}
+			if opts.EndLedger != 0 && startLedger > opts.EndLedger {
+				fail(fmt.Errorf("start ledger %d is after end ledger %d", startLedger, opts.EndLedger))
+				return
+			}
+		}
+
+		for {
+			pagination := &protocol.LedgerPaginationOptions{Cursor: cursor, Limit: limit}
+			if err := protocol.ValidatePagination(startLedger, pagination, MaxPageLimit, ledgerRange); err != nil {
+				fail(err)
+				return
+			}
+
+			p, err := fetch(ctx, startLedger, pagination)
+			if err != nil {
+				fail(err)
+				return
+			}
+			ledgerRange = protocol.LedgerSeqRange{FirstLedger: p.oldestLedger, LastLedger: p.latestLedger}
+
+			for _, item := range p.items {
+				if opts.EndLedger != 0 && ledgerOf(item) > opts.EndLedger {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if p.cursor != "" {
+				cursor = p.cursor
+				startLedger = 0
+				if opts.Checkpoint != nil {
+					if err := opts.Checkpoint.Save(ctx, opts.CheckpointName, cursor); err != nil {
+						fail(err)
+						return
+					}
+				}
+			}
+
+			// A full page means there may be more to read right away.
+			if uint(len(p.items)) >= limit {
+				continue
+			}
+
+			// Otherwise the page ran up to the tip.
+			if !opts.Follow || (opts.EndLedger != 0 && p.latestLedger >= opts.EndLedger) {
+				return
+			}
+
+			latest, err := waitForLedgerAfter(ctx, c, p.latestLedger, interval)
+			if err != nil {
+				fail(err)
+				return
+			}
+			ledgerRange.LastLedger = latest
+		}
+	}
+}
+
+// waitForLedgerAfter polls until a ledger after sequence closes and returns
+// the new latest ledger.
+func waitForLedgerAfter(ctx context.Context, c *RpcClient, sequence uint32, interval time.Duration) (uint32, error) {
+	for {
+		if err := SleepContext(ctx, interval); err != nil {
+			return 0, err
+		}
+
+		latest, err := c.GetLatestLedger(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if latest.Sequence > sequence {
+			return latest.Sequence, nil
+		}
+	}
+}
+
+func decodeSeq[T, D any](seq iter.Seq2[T, error], decode func(T) (D, error)) iter.Seq2[D, error] {
+	return func(yield func(D, error) bool) {
+		var zero D
+		for item, err := range seq {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			decoded, err := decode(item)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(decoded, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package soroban
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/checkpoint"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// newLedgersServer serves getLedgers for ledgers oldest through latest. Each
+// getLatestLedger call closes another ledger.
+func newLedgersServer(t *testing.T, oldest uint32, latest *atomic.Uint32) *mockRPC {
+	return newMockRPC(t, map[string]rpcResult{
+		protocol.GetHealthMethodName: func(rpcRequest) (string, error) {
+			return jsonResult(protocol.GetHealthResponse{Status: "healthy", OldestLedger: oldest, LatestLedger: latest.Load()})
+		},
+		protocol.GetLatestLedgerMethodName: func(rpcRequest) (string, error) {
+			return jsonResult(protocol.GetLatestLedgerResponse{Sequence: latest.Add(1)})
+		},
+		protocol.GetLedgersMethodName: func(req rpcRequest) (string, error) {
+			var params protocol.GetLedgersRequest
+			if err := req.decode(&params); err != nil {
+				return "", err
+			}
+			start := params.StartLedger
+			if params.Pagination.Cursor != "" {
+				cursor, err := strconv.ParseUint(params.Pagination.Cursor, 10, 32)
+				if err != nil {
+					return "", err
+				}
+				start = uint32(cursor) + 1
+			}
+			resp := protocol.GetLedgersResponse{OldestLedger: oldest, LatestLedger: latest.Load()}
+			for seq := start; seq <= resp.LatestLedger && uint(len(resp.Ledgers)) < params.Pagination.Limit; seq++ {
+				resp.Ledgers = append(resp.Ledgers, protocol.LedgerInfo{Sequence: seq})
+			}
+			if len(resp.Ledgers) > 0 {
+				resp.Cursor = strconv.FormatUint(uint64(resp.Ledgers[len(resp.Ledgers)-1].Sequence), 10)
+			}
+			return jsonResult(resp)
+		},
+	})
+}
+
+func TestLedgersIteratesPages(t *testing.T) {
+	var latest atomic.Uint32
+	latest.Store(20)
+	server := newLedgersServer(t, 10, &latest)
+	client := NewClient(server.URL, nil)
+	defer client.Close()
+
+	store := checkpoint.NewMemoryStore()
+	opts := IterOptions{
+		StartLedger:    12,
+		EndLedger:      16,
+		Limit:          2,
+		Checkpoint:     store,
+		CheckpointName: "ledgers",
+	}
+
+	var sequences []uint32
+	for ledger, err := range client.Ledgers(context.Background(), opts) {
+		require.NoError(t, err)
+		sequences = append(sequences, ledger.Sequence)
+	}
+	assert.Equal(t, []uint32{12, 13, 14, 15, 16}, sequences)
+
+	saved, ok, err := store.Load(context.Background(), "ledgers")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "15", saved)
+
+	// Resuming from the checkpoint picks up after the last full page.
+	sequences = nil
+	opts.EndLedger = 18
+	for ledger, err := range client.Ledgers(context.Background(), opts) {
+		require.NoError(t, err)
+		sequences = append(sequences, ledger.Sequence)
+	}
+	assert.Equal(t, []uint32{16, 17, 18}, sequences)
+}
+
+func TestLedgersFollowsTip(t *testing.T) {
+	var latest atomic.Uint32
+	latest.Store(12)
+	server := newLedgersServer(t, 10, &latest)
+	client := NewClient(server.URL, nil)
+	defer client.Close()
+
+	opts := IterOptions{StartLedger: 11, EndLedger: 15, Follow: true, PollInterval: time.Millisecond}
+
+	var sequences []uint32
+	for ledger, err := range client.Ledgers(context.Background(), opts) {
+		require.NoError(t, err)
+		sequences = append(sequences, ledger.Sequence)
+	}
+	assert.Equal(t, []uint32{11, 12, 13, 14, 15}, sequences)
+}
+
+func TestLedgersValidatesPagination(t *testing.T) {
+	var latest atomic.Uint32
+	latest.Store(20)
+	server := newLedgersServer(t, 10, &latest)
+	client := NewClient(server.URL, nil)
+	defer client.Close()
+
+	for _, opts := range []IterOptions{{StartLedger: 5}, {StartLedger: 12, Limit: MaxPageLimit + 1}} {
+		var errs []error
+		for _, err := range client.Ledgers(context.Background(), opts) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.Error(t, errs[0])
+	}
+}
@@ -0,0 +1,147 @@
+package soroban
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/creachadair/jrpc2"
+)
+
+// rpcRequest is a JSON-RPC request received by a mockRPC.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// decode unmarshals the request parameters into params.
+func (r rpcRequest) decode(params any) error {
+	return json.Unmarshal(r.Params, params)
+}
+
+// rpcResult answers a request with a JSON result. A *jrpc2.Error fails the
+// call with that error, a statusError fails the whole HTTP request with its
+// status and any other error fails the call with InvalidParams.
+type rpcResult func(req rpcRequest) (string, error)
+
+// staticResult answers every request with result.
+func staticResult(result string) rpcResult {
+	return func(rpcRequest) (string, error) {
+		return result, nil
+	}
+}
+
+// jsonResult marshals result for an rpcResult to return.
+func jsonResult(result any) (string, error) {
+	body, err := json.Marshal(result)
+	return string(body), err
+}
+
+// statusError fails the HTTP request carrying a call with a bare status, the
+// way a proxy in front of the server does.
+type statusError int
+
+func (e statusError) Error() string {
+	return http.StatusText(int(e))
+}
+
+// mockRPC is a JSON-RPC server that answers each method with its rpcResult,
+// single calls and batches alike.
+//
+// Results run off the test goroutine, so they must not stop the test. They
+// return errors instead, which fail the client call and with it the test.
+type mockRPC struct {
+	URL string
+
+	results  map[string]rpcResult
+	requests atomic.Int32
+}
+
+func newMockRPC(t *testing.T, results map[string]rpcResult) *mockRPC {
+	server := &mockRPC{results: results}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	server.URL = httpServer.URL
+	return server
+}
+
+// roundTrips returns the number of HTTP requests served so far.
+func (s *mockRPC) roundTrips() int32 {
+	return s.requests.Load()
+}
+
+func (s *mockRPC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var req rpcRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rsp, status := s.answer(req)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, rsp)
+		return
+	}
+
+	var reqs []rpcRequest
+	if err := json.Unmarshal(body, &reqs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rsps := make([]string, len(reqs))
+	for i, req := range reqs {
+		rsp, status := s.answer(req)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+		rsps[i] = rsp
+	}
+	fmt.Fprintf(w, "[%s]", strings.Join(rsps, ","))
+}
+
+// answer returns the JSON-RPC response to req, or the HTTP status failing the
+// request it came in.
+func (s *mockRPC) answer(req rpcRequest) (string, int) {
+	result, ok := s.results[req.Method]
+	if !ok {
+		return rpcErrorResponse(req.ID, jrpc2.MethodNotFound, "unexpected method "+req.Method), 0
+	}
+
+	body, err := result(req)
+	var status statusError
+	var rpcErr *jrpc2.Error
+	switch {
+	case err == nil:
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, body), 0
+	case errors.As(err, &status):
+		return "", int(status)
+	case errors.As(err, &rpcErr):
+		return rpcErrorResponse(req.ID, rpcErr.Code, rpcErr.Message), 0
+	default:
+		return rpcErrorResponse(req.ID, jrpc2.InvalidParams, err.Error()), 0
+	}
+}
+
+func rpcErrorResponse(id json.RawMessage, code jrpc2.Code, message string) string {
+	body, _ := json.Marshal(message)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":%s}}`, id, code, body)
+}
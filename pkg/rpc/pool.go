@@ -0,0 +1,299 @@
+package soroban
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/creachadair/jrpc2/jhttp"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+const (
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultMaxLedgerLag        = 10
+	DefaultFailureThreshold    = 1
+
+	healthStatusHealthy = "healthy"
+)
+
+// EndpointStatus is the last known state of one Stellar-RPC endpoint.
+type EndpointStatus struct {
+	URL     string
+	Healthy bool
+	// LatestLedger is the latest ledger reported by the endpoint's last
+	// successful health check.
+	LatestLedger uint32
+	OldestLedger uint32
+	// Lag is how many ledgers this endpoint is behind the freshest endpoint.
+	Lag                 uint32
+	ConsecutiveFailures int
+	LastError           error
+	LastChecked         time.Time
+	// Active is set on the endpoint calls are currently routed to.
+	Active bool
+}
+
+type endpoint struct {
+	url        string
+	httpClient *http.Client
+	cli        *jrpc2.Client
+	mx         sync.RWMutex // to protect cli writes in refreshes
+
+	statusMx sync.RWMutex
+	status   EndpointStatus
+}
+
+func newEndpoint(url string, httpClient *http.Client) *endpoint {
+	e := &endpoint{
+		url:        url,
+		httpClient: httpClient,
+		status:     EndpointStatus{URL: url, Healthy: true},
+	}
+	e.refreshClient()
+	return e
+}
+
+func (e *endpoint) refreshClient() {
+	var opts *jhttp.ChannelOptions
+	if e.httpClient != nil {
+		opts = &jhttp.ChannelOptions{
+			Client: e.httpClient,
+		}
+	}
+	ch := jhttp.NewChannel(e.url, opts)
+	cli := jrpc2.NewClient(ch, nil)
+
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	if e.cli != nil {
+		e.cli.Close()
+	}
+	e.cli = cli
+}
+
+func (e *endpoint) close() error {
+	e.mx.RLock()
+	defer e.mx.RUnlock()
+	return e.cli.Close()
+}
+
+func (e *endpoint) callResult(ctx context.Context, method string, params, result any) error {
+	e.mx.RLock()
+	err := e.cli.CallResult(ctx, method, params, result)
+	e.mx.RUnlock()
+	if err != nil {
+		// This is needed because of https://github.com/creachadair/jrpc2/issues/118
+		e.refreshClient()
+	}
+	return err
+}
+
+func (e *endpoint) snapshot() EndpointStatus {
+	e.statusMx.RLock()
+	defer e.statusMx.RUnlock()
+	return e.status
+}
+
+// recordCall updates the endpoint's status after a routed call.
+func (e *endpoint) recordCall(err error, failureThreshold int) {
+	e.statusMx.Lock()
+	defer e.statusMx.Unlock()
+
+	if err == nil {
+		e.status.ConsecutiveFailures = 0
+		e.status.Healthy = true
+		return
+	}
+
+	switch ClassifyError(err) {
+	case ErrorClassTransport, ErrorClassRateLimited, ErrorClassUnavailable, ErrorClassServer:
+		e.status.ConsecutiveFailures++
+		e.status.LastError = err
+		if e.status.ConsecutiveFailures >= failureThreshold {
+			e.status.Healthy = false
+		}
+	}
+}
+
+// check probes the endpoint with getHealth and getLatestLedger.
+func (e *endpoint) check(ctx context.Context) {
+	var health protocol.GetHealthResponse
+	var latest protocol.GetLatestLedgerResponse
+
+	err := e.callResult(ctx, protocol.GetHealthMethodName, nil, &health)
+	if err == nil && health.Status != healthStatusHealthy {
+		err = fmt.Errorf("endpoint reported status %q", health.Status)
+	}
+	if err == nil {
+		err = e.callResult(ctx, protocol.GetLatestLedgerMethodName, nil, &latest)
+	}
+
+	e.statusMx.Lock()
+	defer e.statusMx.Unlock()
+	e.status.LastChecked = time.Now()
+	if err != nil {
+		e.status.Healthy = false
+		e.status.ConsecutiveFailures++
+		e.status.LastError = err
+		return
+	}
+
+	e.status.Healthy = true
+	e.status.ConsecutiveFailures = 0
+	e.status.LastError = nil
+	e.status.LatestLedger = max(health.LatestLedger, latest.Sequence)
+	e.status.OldestLedger = health.OldestLedger
+}
+
+// WithHealthCheckInterval sets how often a pool client polls its endpoints.
+func WithHealthCheckInterval(interval time.Duration) ClientOption {
+	return func(c *RpcClient) {
+		c.healthCheckInterval = interval
+	}
+}
+
+// WithMaxLedgerLag sets how many ledgers an endpoint may fall behind the
+// freshest endpoint before calls fail over away from it.
+func WithMaxLedgerLag(lag uint32) ClientOption {
+	return func(c *RpcClient) {
+		c.maxLedgerLag = lag
+	}
+}
+
+// WithFailureThreshold sets how many consecutive failed calls mark an
+// endpoint unhealthy until its next successful health check.
+func WithFailureThreshold(failures int) ClientOption {
+	return func(c *RpcClient) {
+		c.failureThreshold = failures
+	}
+}
+
+// NewPoolClient creates a client that spreads calls over several Stellar-RPC
+// endpoints. Calls go to the freshest healthy endpoint, preferring endpoints
+// listed earlier on ties, and fail over when an endpoint errors or lags.
+func NewPoolClient(urls []string, httpClient *http.Client, opts ...ClientOption) (*RpcClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("at least one endpoint url is required")
+	}
+
+	c := newClient(urls, httpClient, opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopHealthChecks = cancel
+	c.healthChecks.Add(1)
+	go c.monitorHealth(ctx)
+
+	return c, nil
+}
+
+// EndpointStatuses reports the state of every endpoint in the order they were
+// configured.
+func (c *RpcClient) EndpointStatuses() []EndpointStatus {
+	statuses := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		statuses[i] = e.snapshot()
+	}
+
+	var freshest uint32
+	for _, status := range statuses {
+		freshest = max(freshest, status.LatestLedger)
+	}
+	active := c.pickIndex(statuses)
+	for i := range statuses {
+		statuses[i].Lag = freshest - statuses[i].LatestLedger
+		statuses[i].Active = i == active
+	}
+	return statuses
+}
+
+// CheckHealth probes every endpoint once and waits for the results.
+func (c *RpcClient) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range c.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			e.check(ctx)
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (c *RpcClient) monitorHealth(ctx context.Context) {
+	defer c.healthChecks.Done()
+
+	interval := c.healthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		c.CheckHealth(checkCtx)
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pickEndpoint returns the endpoint the next call should be routed to. An
+// endpoint in skip is only picked when it is the only one.
+func (c *RpcClient) pickEndpoint(skip ...*endpoint) *endpoint {
+	if len(c.endpoints) == 1 {
+		return c.endpoints[0]
+	}
+
+	statuses := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		statuses[i] = e.snapshot()
+		if slices.Contains(skip, e) {
+			statuses[i].Healthy = false
+			statuses[i].ConsecutiveFailures = math.MaxInt
+		}
+	}
+	return c.endpoints[c.pickIndex(statuses)]
+}
+
+func (c *RpcClient) pickIndex(statuses []EndpointStatus) int {
+	var freshest uint32
+	for _, status := range statuses {
+		if status.Healthy {
+			freshest = max(freshest, status.LatestLedger)
+		}
+	}
+
+	best := -1
+	for i, status := range statuses {
+		if !status.Healthy || freshest-status.LatestLedger > c.maxLedgerLag {
+			continue
+		}
+		if best == -1 || status.LatestLedger > statuses[best].LatestLedger {
+			best = i
+		}
+	}
+	if best != -1 {
+		return best
+	}
+
+	// Nothing is healthy, so fall back to whichever endpoint failed least.
+	best = 0
+	for i, status := range statuses {
+		if status.ConsecutiveFailures < statuses[best].ConsecutiveFailures {
+			best = i
+		}
+	}
+	return best
+}
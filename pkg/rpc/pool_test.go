@@ -0,0 +1,99 @@
+package soroban
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+// newLedgerServer serves the health and latest ledger of a node at
+// latestLedger, or fails every request with 503 when fail is set.
+func newLedgerServer(t *testing.T, latestLedger uint32, fail bool) *mockRPC {
+	health := staticResult(fmt.Sprintf(`{"status":"healthy","latestLedger":%d}`, latestLedger))
+	latest := staticResult(fmt.Sprintf(`{"sequence":%d}`, latestLedger))
+	if fail {
+		unavailable := func(rpcRequest) (string, error) {
+			return "", statusError(http.StatusServiceUnavailable)
+		}
+		health, latest = unavailable, unavailable
+	}
+	return newMockRPC(t, map[string]rpcResult{
+		protocol.GetHealthMethodName:       health,
+		protocol.GetLatestLedgerMethodName: latest,
+	})
+}
+
+func TestPoolClientRoutesToFreshestEndpoint(t *testing.T) {
+	lagging := newLedgerServer(t, 100, false)
+	fresh := newLedgerServer(t, 150, false)
+
+	client, err := NewPoolClient([]string{lagging.URL, fresh.URL}, nil,
+		WithHealthCheckInterval(time.Hour),
+		WithMaxLedgerLag(10),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	client.CheckHealth(context.Background())
+
+	latest, err := client.GetLatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(150), latest.Sequence)
+
+	statuses := client.EndpointStatuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, uint32(50), statuses[0].Lag)
+	assert.False(t, statuses[0].Active)
+	assert.True(t, statuses[1].Active)
+}
+
+func TestPoolClientFailsOver(t *testing.T) {
+	broken := newLedgerServer(t, 0, true)
+	backup := newLedgerServer(t, 200, false)
+
+	client, err := NewPoolClient([]string{broken.URL, backup.URL}, nil,
+		WithHealthCheckInterval(time.Hour),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	latest, err := client.GetLatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(200), latest.Sequence)
+
+	statuses := client.EndpointStatuses()
+	assert.False(t, statuses[0].Healthy)
+	assert.Error(t, statuses[0].LastError)
+	assert.True(t, statuses[1].Active)
+}
+
+func TestPoolClientFailsOverWithoutRetries(t *testing.T) {
+	broken := newLedgerServer(t, 0, true)
+	backup := newLedgerServer(t, 200, false)
+
+	// No health checks, so only failed calls move traffic off broken.
+	client := newClient([]string{broken.URL, backup.URL}, nil,
+		WithRetryPolicy(NoRetry()),
+		WithFailureThreshold(3),
+	)
+	defer client.Close()
+
+	for range 2 {
+		latest, err := client.GetLatestLedger(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(200), latest.Sequence)
+	}
+
+	statuses := client.EndpointStatuses()
+	assert.Equal(t, 2, statuses[0].ConsecutiveFailures)
+	assert.True(t, statuses[0].Healthy)
+	assert.Equal(t, 0, statuses[1].ConsecutiveFailures)
+}
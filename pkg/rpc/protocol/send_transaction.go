@@ -2,7 +2,21 @@ package protocol
 
 import "encoding/json"
 
-const SendTransactionMethodName = "sendTransaction"
+const (
+	SendTransactionMethodName = "sendTransaction"
+	// SendTransactionStatusPending indicates the transaction was accepted by
+	// stellar-core and is waiting to be included in a ledger.
+	SendTransactionStatusPending = "PENDING"
+	// SendTransactionStatusDuplicate indicates stellar-core already knows about
+	// the transaction.
+	SendTransactionStatusDuplicate = "DUPLICATE"
+	// SendTransactionStatusTryAgainLater indicates stellar-core could not accept
+	// the transaction right now and it should be resubmitted later.
+	SendTransactionStatusTryAgainLater = "TRY_AGAIN_LATER"
+	// SendTransactionStatusError indicates stellar-core rejected the
+	// transaction, see ErrorResultXDR.
+	SendTransactionStatusError = "ERROR"
+)
 
 // SendTransactionResponse represents the transaction submission response returned Stellar-RPC
 type SendTransactionResponse struct {
@@ -0,0 +1,212 @@
+package soroban
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/creachadair/jrpc2"
+)
+
+// RetryPolicy controls how many times a call is attempted and how long the
+// client waits between attempts. Delays grow exponentially from
+// InitialBackoff by Multiplier up to MaxBackoff, and each delay is reduced by
+// a random fraction of up to Jitter (0 to 1) so that clients hitting the same
+// node do not retry in lockstep.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// NoRetry makes a single attempt per call.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// Backoff returns the delay to wait after the given (1-based) failed attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		delay -= rand.Float64() * jitter * delay
+	}
+
+	return time.Duration(delay)
+}
+
+// ErrorClass groups call failures by whether retrying them can help.
+type ErrorClass int
+
+const (
+	// ErrorClassNone is reported for a nil error.
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassPermanent covers deterministic failures such as invalid
+	// params, unknown methods or undecodable results. Retrying will not help.
+	ErrorClassPermanent
+	// ErrorClassCanceled means the caller's context ended.
+	ErrorClassCanceled
+	// ErrorClassTransport covers connection failures and dropped responses.
+	// The request may or may not have reached the server.
+	ErrorClassTransport
+	// ErrorClassRateLimited is an HTTP 429 response.
+	ErrorClassRateLimited
+	// ErrorClassUnavailable is an HTTP 502, 503 or 504 response.
+	ErrorClassUnavailable
+	// ErrorClassServer is a JSON-RPC internal or implementation-defined
+	// server error.
+	ErrorClassServer
+)
+
+func (e ErrorClass) String() string {
+	switch e {
+	case ErrorClassNone:
+		return "none"
+	case ErrorClassPermanent:
+		return "permanent"
+	case ErrorClassCanceled:
+		return "canceled"
+	case ErrorClassTransport:
+		return "transport"
+	case ErrorClassRateLimited:
+		return "rate limited"
+	case ErrorClassUnavailable:
+		return "unavailable"
+	case ErrorClassServer:
+		return "server"
+	default:
+		return "unknown"
+	}
+}
+
+// Retryable reports whether a call failing with this class may succeed if
+// attempted again.
+func (e ErrorClass) Retryable() bool {
+	switch e {
+	case ErrorClassTransport, ErrorClassRateLimited, ErrorClassUnavailable, ErrorClassServer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Rejected reports whether the server turned the request away before
+// processing it, which makes it safe to resend even non-idempotent calls.
+func (e ErrorClass) Rejected() bool {
+	return e == ErrorClassRateLimited || e == ErrorClassUnavailable
+}
+
+// jhttp reports non-200 replies as "unexpected HTTP status 429 Too Many Requests"
+var httpStatusPattern = regexp.MustCompile(`unexpected HTTP status (\d{3})`)
+
+// ClassifyError sorts an error returned by an RpcClient call into an ErrorClass.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassCanceled
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return ErrorClassPermanent
+	}
+
+	if status, ok := httpStatus(err); ok {
+		switch status {
+		case http.StatusTooManyRequests:
+			return ErrorClassRateLimited
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return ErrorClassUnavailable
+		}
+		if status >= http.StatusInternalServerError {
+			return ErrorClassServer
+		}
+		return ErrorClassPermanent
+	}
+
+	var rpcErr *jrpc2.Error
+	if !errors.As(err, &rpcErr) {
+		// Anything that is not a JSON-RPC error object came from the channel.
+		return ErrorClassTransport
+	}
+
+	switch code := rpcErr.Code; {
+	case code == jrpc2.Cancelled || code == jrpc2.DeadlineExceeded:
+		return ErrorClassCanceled
+	case code == jrpc2.ParseError, code == jrpc2.InvalidRequest,
+		code == jrpc2.MethodNotFound, code == jrpc2.InvalidParams:
+		return ErrorClassPermanent
+	case code == jrpc2.SystemError:
+		return ErrorClassTransport
+	case code == jrpc2.InternalError:
+		// jrpc2 also reports a failed HTTP round trip as an internal error
+		return ErrorClassServer
+	case code >= -32099 && code <= -32000:
+		return ErrorClassServer
+	default:
+		return ErrorClassPermanent
+	}
+}
+
+func httpStatus(err error) (int, bool) {
+	match := httpStatusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	status, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return 0, false
+	}
+	return status, true
+}
+
+// SleepContext waits for d, or returns early with the context's error once
+// ctx is done.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
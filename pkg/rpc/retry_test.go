@@ -0,0 +1,106 @@
+package soroban
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/creachadair/jrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/rpc/protocol"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, testCase := range []struct {
+		err   error
+		class ErrorClass
+	}{
+		{nil, ErrorClassNone},
+		{context.DeadlineExceeded, ErrorClassCanceled},
+		{&jrpc2.Error{Code: jrpc2.Cancelled}, ErrorClassCanceled},
+		{&jrpc2.Error{Code: jrpc2.InvalidParams, Message: "bad"}, ErrorClassPermanent},
+		{&jrpc2.Error{Code: jrpc2.MethodNotFound}, ErrorClassPermanent},
+		{&jrpc2.Error{Code: jrpc2.InternalError, Message: "db is down"}, ErrorClassServer},
+		{&jrpc2.Error{Code: -32001, Message: "busy"}, ErrorClassServer},
+		{&jrpc2.Error{Code: jrpc2.InternalError, Message: "unexpected HTTP status 429 Too Many Requests"}, ErrorClassRateLimited},
+		{&jrpc2.Error{Code: jrpc2.InternalError, Message: "unexpected HTTP status 503 Service Unavailable"}, ErrorClassUnavailable},
+		{&jrpc2.Error{Code: jrpc2.InternalError, Message: "unexpected HTTP status 404 Not Found"}, ErrorClassPermanent},
+		{errors.New("dial tcp: connection refused"), ErrorClassTransport},
+		{&json.UnmarshalTypeError{Value: "string"}, ErrorClassPermanent},
+	} {
+		assert.Equal(t, testCase.class, ClassifyError(testCase.err), fmt.Sprintf("%v", testCase.err))
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(3))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		delay := policy.Backoff(1)
+		assert.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		assert.LessOrEqual(t, delay, 100*time.Millisecond)
+	}
+}
+
+func TestCallResultRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := newMockRPC(t, map[string]rpcResult{
+		protocol.GetHealthMethodName: func(rpcRequest) (string, error) {
+			switch calls.Add(1) {
+			case 1:
+				return "", statusError(http.StatusServiceUnavailable)
+			case 2:
+				return "", statusError(http.StatusTooManyRequests)
+			default:
+				return `{"status":"healthy","latestLedger":10}`, nil
+			}
+		},
+	})
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	client := NewClient(server.URL, nil, WithRetryPolicy(policy))
+	defer client.Close()
+
+	health, err := client.GetHealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(10), health.LatestLedger)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCallResultDoesNotRetryPermanentErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := newMockRPC(t, map[string]rpcResult{
+		protocol.GetTransactionMethodName: func(rpcRequest) (string, error) {
+			calls.Add(1)
+			return "", &jrpc2.Error{Code: jrpc2.InvalidParams, Message: "invalid params"}
+		},
+	})
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}
+	client := NewClient(server.URL, nil,
+		WithRetryPolicy(NoRetry()),
+		WithMethodRetryPolicy(protocol.GetTransactionMethodName, policy),
+	)
+	defer client.Close()
+
+	_, err := client.GetTransaction(context.Background(), protocol.GetTransactionRequest{Hash: "abc"})
+	require.Error(t, err)
+	assert.Equal(t, ErrorClassPermanent, ClassifyError(err))
+	assert.Equal(t, int32(1), calls.Load())
+}
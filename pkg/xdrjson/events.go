@@ -0,0 +1,112 @@
+package xdrjson
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+var eventTypes = map[string]xdr.ContractEventType{
+	"system":     xdr.ContractEventTypeSystem,
+	"contract":   xdr.ContractEventTypeContract,
+	"diagnostic": xdr.ContractEventTypeDiagnostic,
+}
+
+// UnmarshalScVals parses a list of JSON ScVals, such as an event's topics.
+func UnmarshalScVals(items []json.RawMessage) ([]xdr.ScVal, error) {
+	vals := make([]xdr.ScVal, len(items))
+	for idx, item := range items {
+		val, err := UnmarshalScVal(item)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", idx, err)
+		}
+		vals[idx] = val
+	}
+	return vals, nil
+}
+
+// UnmarshalContractEvent parses the JSON form of a ContractEvent.
+func UnmarshalContractEvent(data []byte) (xdr.ContractEvent, error) {
+	var raw struct {
+		ContractID *string `json:"contract_id"`
+		Type       string  `json:"type_"`
+		Body       struct {
+			V0 *struct {
+				Topics []json.RawMessage `json:"topics"`
+				Data   json.RawMessage   `json:"data"`
+			} `json:"v0"`
+		} `json:"body"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return xdr.ContractEvent{}, fmt.Errorf("invalid contract event: %w", err)
+	}
+
+	eventType, ok := eventTypes[raw.Type]
+	if !ok {
+		return xdr.ContractEvent{}, fmt.Errorf("unknown contract event type %q", raw.Type)
+	}
+	if raw.Body.V0 == nil {
+		return xdr.ContractEvent{}, fmt.Errorf("unsupported contract event body")
+	}
+
+	event := xdr.ContractEvent{Type: eventType}
+
+	if raw.ContractID != nil {
+		contractID, err := parseContractID(*raw.ContractID)
+		if err != nil {
+			return xdr.ContractEvent{}, err
+		}
+		event.ContractId = &contractID
+	}
+
+	topics, err := UnmarshalScVals(raw.Body.V0.Topics)
+	if err != nil {
+		return xdr.ContractEvent{}, fmt.Errorf("invalid event topics: %w", err)
+	}
+	eventData, err := UnmarshalScVal(raw.Body.V0.Data)
+	if err != nil {
+		return xdr.ContractEvent{}, fmt.Errorf("invalid event data: %w", err)
+	}
+	event.Body = xdr.ContractEventBody{
+		V:  0,
+		V0: &xdr.ContractEventV0{Topics: topics, Data: eventData},
+	}
+
+	return event, nil
+}
+
+// UnmarshalDiagnosticEvent parses the JSON form of a DiagnosticEvent.
+func UnmarshalDiagnosticEvent(data []byte) (xdr.DiagnosticEvent, error) {
+	var raw struct {
+		InSuccessfulContractCall bool            `json:"in_successful_contract_call"`
+		Event                    json.RawMessage `json:"event"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return xdr.DiagnosticEvent{}, fmt.Errorf("invalid diagnostic event: %w", err)
+	}
+
+	event, err := UnmarshalContractEvent(raw.Event)
+	if err != nil {
+		return xdr.DiagnosticEvent{}, err
+	}
+	return xdr.DiagnosticEvent{InSuccessfulContractCall: raw.InSuccessfulContractCall, Event: event}, nil
+}
+
+// parseContractID accepts a contract strkey or, as older stellar-xdr
+// versions rendered it, a hex hash.
+func parseContractID(s string) (xdr.ContractId, error) {
+	var contractID xdr.ContractId
+
+	raw, err := strkey.Decode(strkey.VersionByteContract, s)
+	if err != nil {
+		raw, err = hex.DecodeString(s)
+		if err != nil || len(raw) != len(contractID) {
+			return contractID, fmt.Errorf("invalid contract id %q", s)
+		}
+	}
+	copy(contractID[:], raw)
+	return contractID, nil
+}
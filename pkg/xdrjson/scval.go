@@ -0,0 +1,574 @@
+// Package xdrjson converts between Soroban XDR values and the JSON form
+// Stellar-RPC returns when a request sets xdrFormat to "json". The JSON form
+// is the serde representation of the Rust stellar-xdr crate: enums are
+// snake_case objects keyed by variant, 64-bit and wider integers are decimal
+// strings, and bytes are hex.
+package xdrjson
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/stellar/go/xdr"
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+const (
+	variantVoid                      = "void"
+	variantLedgerKeyContractInstance = "ledger_key_contract_instance"
+	executableStellarAsset           = "stellar_asset"
+)
+
+// UnmarshalScVal parses the JSON form of an ScVal.
+func UnmarshalScVal(data []byte) (xdr.ScVal, error) {
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '"' {
+		var variant string
+		if err := json.Unmarshal(data, &variant); err != nil {
+			return xdr.ScVal{}, err
+		}
+		switch variant {
+		case variantVoid:
+			return xdr.ScVal{Type: xdr.ScValTypeScvVoid}, nil
+		case variantLedgerKeyContractInstance:
+			return xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, nil
+		default:
+			return xdr.ScVal{}, fmt.Errorf("unknown ScVal variant %q", variant)
+		}
+	}
+
+	variant, value, err := singleKey(data)
+	if err != nil {
+		return xdr.ScVal{}, fmt.Errorf("invalid ScVal: %w", err)
+	}
+
+	switch variant {
+	case "bool":
+		var b bool
+		if err := json.Unmarshal(value, &b); err != nil {
+			return xdr.ScVal{}, fmt.Errorf("invalid bool: %w", err)
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}, nil
+	case variantVoid:
+		return xdr.ScVal{Type: xdr.ScValTypeScvVoid}, nil
+	case "error":
+		scErr, err := unmarshalScError(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &scErr}, nil
+	case "u32":
+		n, err := parseUint(value, 32)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		u32 := xdr.Uint32(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &u32}, nil
+	case "i32":
+		n, err := parseInt(value, 32)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		i32 := xdr.Int32(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &i32}, nil
+	case "u64":
+		n, err := parseUint(value, 64)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		u64 := xdr.Uint64(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvU64, U64: &u64}, nil
+	case "i64":
+		n, err := parseInt(value, 64)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		i64 := xdr.Int64(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &i64}, nil
+	case "timepoint":
+		n, err := parseUint(value, 64)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		timepoint := xdr.TimePoint(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvTimepoint, Timepoint: &timepoint}, nil
+	case "duration":
+		n, err := parseUint(value, 64)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		duration := xdr.Duration(n)
+		return xdr.ScVal{Type: xdr.ScValTypeScvDuration, Duration: &duration}, nil
+	case "u128":
+		n, err := parseBig(value, "hi", "lo")
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		u128, err := helpers.BigIntToU128(n)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvU128, U128: &u128}, nil
+	case "i128":
+		n, err := parseBig(value, "hi", "lo")
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		i128, err := helpers.BigIntToI128(n)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &i128}, nil
+	case "u256":
+		n, err := parseBig(value, "hi_hi", "hi_lo", "lo_hi", "lo_lo")
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		u256, err := helpers.BigIntToU256(n)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvU256, U256: &u256}, nil
+	case "i256":
+		n, err := parseBig(value, "hi_hi", "hi_lo", "lo_hi", "lo_lo")
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		i256, err := helpers.BigIntToI256(n)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvI256, I256: &i256}, nil
+	case "bytes":
+		b, err := parseHex(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		scBytes := xdr.ScBytes(b)
+		return xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &scBytes}, nil
+	case "string":
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return xdr.ScVal{}, fmt.Errorf("invalid string: %w", err)
+		}
+		scString := xdr.ScString(s)
+		return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &scString}, nil
+	case "symbol":
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return xdr.ScVal{}, fmt.Errorf("invalid symbol: %w", err)
+		}
+		sym := xdr.ScSymbol(s)
+		return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, nil
+	case "vec":
+		vec, err := unmarshalVec(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec}, nil
+	case "map":
+		scMap, err := unmarshalMap(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &scMap}, nil
+	case "address":
+		address, err := unmarshalAddress(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &address}, nil
+	case "ledger_key_nonce":
+		var nonce struct {
+			Nonce json.RawMessage `json:"nonce"`
+		}
+		if err := json.Unmarshal(value, &nonce); err != nil {
+			return xdr.ScVal{}, fmt.Errorf("invalid nonce key: %w", err)
+		}
+		n, err := parseInt(nonce.Nonce, 64)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{
+			Type:     xdr.ScValTypeScvLedgerKeyNonce,
+			NonceKey: &xdr.ScNonceKey{Nonce: xdr.Int64(n)},
+		}, nil
+	case "contract_instance":
+		instance, err := unmarshalContractInstance(value)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &instance}, nil
+	default:
+		return xdr.ScVal{}, fmt.Errorf("unknown ScVal variant %q", variant)
+	}
+}
+
+// MarshalScVal renders an ScVal in the JSON form UnmarshalScVal parses.
+func MarshalScVal(val xdr.ScVal) ([]byte, error) {
+	v, err := scValJSON(val)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func scValJSON(val xdr.ScVal) (any, error) {
+	switch val.Type {
+	case xdr.ScValTypeScvBool:
+		return map[string]any{"bool": val.MustB()}, nil
+	case xdr.ScValTypeScvVoid:
+		return variantVoid, nil
+	case xdr.ScValTypeScvError:
+		scErr, err := scErrorJSON(val.MustError())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"error": scErr}, nil
+	case xdr.ScValTypeScvU32:
+		return map[string]any{"u32": uint32(val.MustU32())}, nil
+	case xdr.ScValTypeScvI32:
+		return map[string]any{"i32": int32(val.MustI32())}, nil
+	case xdr.ScValTypeScvU64:
+		return map[string]any{"u64": strconv.FormatUint(uint64(val.MustU64()), 10)}, nil
+	case xdr.ScValTypeScvI64:
+		return map[string]any{"i64": strconv.FormatInt(int64(val.MustI64()), 10)}, nil
+	case xdr.ScValTypeScvTimepoint:
+		return map[string]any{"timepoint": strconv.FormatUint(uint64(val.MustTimepoint()), 10)}, nil
+	case xdr.ScValTypeScvDuration:
+		return map[string]any{"duration": strconv.FormatUint(uint64(val.MustDuration()), 10)}, nil
+	case xdr.ScValTypeScvU128:
+		return map[string]any{"u128": helpers.U128ToBigInt(val.MustU128()).String()}, nil
+	case xdr.ScValTypeScvI128:
+		return map[string]any{"i128": helpers.I128ToBigInt(val.MustI128()).String()}, nil
+	case xdr.ScValTypeScvU256:
+		return map[string]any{"u256": helpers.U256ToBigInt(val.MustU256()).String()}, nil
+	case xdr.ScValTypeScvI256:
+		return map[string]any{"i256": helpers.I256ToBigInt(val.MustI256()).String()}, nil
+	case xdr.ScValTypeScvBytes:
+		return map[string]any{"bytes": hex.EncodeToString(val.MustBytes())}, nil
+	case xdr.ScValTypeScvString:
+		return map[string]any{"string": string(val.MustStr())}, nil
+	case xdr.ScValTypeScvSymbol:
+		return map[string]any{"symbol": string(val.MustSym())}, nil
+	case xdr.ScValTypeScvVec:
+		vec, err := vecJSON(val.MustVec())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"vec": vec}, nil
+	case xdr.ScValTypeScvMap:
+		scMap, err := mapJSON(val.MustMap())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"map": scMap}, nil
+	case xdr.ScValTypeScvAddress:
+		address, err := helpers.EncodeScAddress(val.MustAddress())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"address": address}, nil
+	case xdr.ScValTypeScvLedgerKeyNonce:
+		nonce := strconv.FormatInt(int64(val.MustNonceKey().Nonce), 10)
+		return map[string]any{"ledger_key_nonce": map[string]any{"nonce": nonce}}, nil
+	case xdr.ScValTypeScvLedgerKeyContractInstance:
+		return variantLedgerKeyContractInstance, nil
+	case xdr.ScValTypeScvContractInstance:
+		instance := val.MustInstance()
+		var executable any = executableStellarAsset
+		if instance.Executable.Type == xdr.ContractExecutableTypeContractExecutableWasm {
+			wasmHash := instance.Executable.MustWasmHash()
+			executable = map[string]any{"wasm": hex.EncodeToString(wasmHash[:])}
+		}
+		storage, err := mapJSON(instance.Storage)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"contract_instance": map[string]any{
+			"executable": executable,
+			"storage":    storage,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported ScVal type %s", val.Type)
+	}
+}
+
+func vecJSON(vec *xdr.ScVec) (any, error) {
+	if vec == nil {
+		return nil, nil
+	}
+	items := make([]any, len(*vec))
+	for idx, item := range *vec {
+		v, err := scValJSON(item)
+		if err != nil {
+			return nil, err
+		}
+		items[idx] = v
+	}
+	return items, nil
+}
+
+func mapJSON(scMap *xdr.ScMap) (any, error) {
+	if scMap == nil {
+		return nil, nil
+	}
+	entries := make([]any, len(*scMap))
+	for idx, entry := range *scMap {
+		key, err := scValJSON(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := scValJSON(entry.Val)
+		if err != nil {
+			return nil, err
+		}
+		entries[idx] = map[string]any{"key": key, "val": val}
+	}
+	return entries, nil
+}
+
+func scErrorJSON(scErr xdr.ScError) (any, error) {
+	typeName, ok := helpers.ScErrorTypeName(scErr.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown ScError type %d", scErr.Type)
+	}
+	if scErr.Type == xdr.ScErrorTypeSceContract {
+		return map[string]any{typeName: uint32(scErr.MustContractCode())}, nil
+	}
+	codeName, ok := helpers.ScErrorCodeName(scErr.MustCode())
+	if !ok {
+		return nil, fmt.Errorf("unknown ScError code %d", scErr.MustCode())
+	}
+	return map[string]any{typeName: codeName}, nil
+}
+
+func unmarshalScError(data []byte) (xdr.ScError, error) {
+	typeName, value, err := singleKey(data)
+	if err != nil {
+		return xdr.ScError{}, fmt.Errorf("invalid error: %w", err)
+	}
+
+	errType, ok := helpers.ParseScErrorType(typeName)
+	if !ok {
+		return xdr.ScError{}, fmt.Errorf("unknown error type %q", typeName)
+	}
+
+	if errType == xdr.ScErrorTypeSceContract {
+		code, err := parseUint(value, 32)
+		if err != nil {
+			return xdr.ScError{}, err
+		}
+		contractCode := xdr.Uint32(code)
+		return xdr.ScError{Type: errType, ContractCode: &contractCode}, nil
+	}
+
+	var codeName string
+	if err := json.Unmarshal(value, &codeName); err != nil {
+		return xdr.ScError{}, fmt.Errorf("invalid error code: %w", err)
+	}
+	code, ok := helpers.ParseScErrorCode(codeName)
+	if !ok {
+		return xdr.ScError{}, fmt.Errorf("unknown error code %q", codeName)
+	}
+	return xdr.ScError{Type: errType, Code: &code}, nil
+}
+
+func unmarshalVec(data []byte) (*xdr.ScVec, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid vec: %w", err)
+	}
+	if items == nil {
+		return nil, nil
+	}
+
+	vec := make(xdr.ScVec, len(items))
+	for idx, item := range items {
+		val, err := UnmarshalScVal(item)
+		if err != nil {
+			return nil, fmt.Errorf("vec item %d: %w", idx, err)
+		}
+		vec[idx] = val
+	}
+	return &vec, nil
+}
+
+func unmarshalMap(data []byte) (*xdr.ScMap, error) {
+	var entries []struct {
+		Key json.RawMessage `json:"key"`
+		Val json.RawMessage `json:"val"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid map: %w", err)
+	}
+	if entries == nil {
+		return nil, nil
+	}
+
+	scMap := make(xdr.ScMap, len(entries))
+	for idx, entry := range entries {
+		key, err := UnmarshalScVal(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("map key %d: %w", idx, err)
+		}
+		val, err := UnmarshalScVal(entry.Val)
+		if err != nil {
+			return nil, fmt.Errorf("map value %d: %w", idx, err)
+		}
+		scMap[idx] = xdr.ScMapEntry{Key: key, Val: val}
+	}
+	return &scMap, nil
+}
+
+func unmarshalContractInstance(data []byte) (xdr.ScContractInstance, error) {
+	var raw struct {
+		Executable json.RawMessage `json:"executable"`
+		Storage    json.RawMessage `json:"storage"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return xdr.ScContractInstance{}, fmt.Errorf("invalid contract instance: %w", err)
+	}
+
+	var instance xdr.ScContractInstance
+	var executable string
+	if err := json.Unmarshal(raw.Executable, &executable); err == nil {
+		if executable != executableStellarAsset {
+			return xdr.ScContractInstance{}, fmt.Errorf("unknown contract executable %q", executable)
+		}
+		instance.Executable = xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableStellarAsset}
+	} else {
+		variant, value, err := singleKey(raw.Executable)
+		if err != nil || variant != "wasm" {
+			return xdr.ScContractInstance{}, fmt.Errorf("invalid contract executable %s", raw.Executable)
+		}
+		hashBytes, err := parseHex(value)
+		if err != nil {
+			return xdr.ScContractInstance{}, err
+		}
+		var wasmHash xdr.Hash
+		if len(hashBytes) != len(wasmHash) {
+			return xdr.ScContractInstance{}, fmt.Errorf("wasm hash must be %d bytes", len(wasmHash))
+		}
+		copy(wasmHash[:], hashBytes)
+		instance.Executable = xdr.ContractExecutable{
+			Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+			WasmHash: &wasmHash,
+		}
+	}
+
+	if len(raw.Storage) > 0 {
+		storage, err := unmarshalMap(raw.Storage)
+		if err != nil {
+			return xdr.ScContractInstance{}, err
+		}
+		instance.Storage = storage
+	}
+	return instance, nil
+}
+
+func unmarshalAddress(data []byte) (xdr.ScAddress, error) {
+	var address string
+	if err := json.Unmarshal(data, &address); err != nil {
+		return xdr.ScAddress{}, fmt.Errorf("invalid address: %w", err)
+	}
+
+	return helpers.ParseScAddress(address)
+}
+
+// singleKey unpacks an externally tagged enum value such as {"u32": 1}.
+func singleKey(data []byte) (string, json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", nil, err
+	}
+	if len(obj) != 1 {
+		return "", nil, fmt.Errorf("expected an object with one key, got %d keys", len(obj))
+	}
+	for key, value := range obj {
+		return key, value, nil
+	}
+	panic("unreachable")
+}
+
+// numberText returns the text of a JSON number or of a string holding one.
+func numberText(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", fmt.Errorf("invalid number %s", data)
+	}
+	return n.String(), nil
+}
+
+func parseUint(data []byte, bits int) (uint64, error) {
+	text, err := numberText(data)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(text, 10, bits)
+}
+
+func parseInt(data []byte, bits int) (int64, error) {
+	text, err := numberText(data)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(text, 10, bits)
+}
+
+// parseBig parses a wide integer written either as a decimal string or, as
+// older stellar-xdr versions did, as an object of 64-bit parts named by
+// parts from most to least significant. Only the most significant part is
+// signed.
+func parseBig(data []byte, parts ...string) (*big.Int, error) {
+	if text, err := numberText(data); err == nil {
+		n, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", text)
+		}
+		return n, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("invalid integer %s", data)
+	}
+
+	value := new(big.Int)
+	for idx, part := range parts {
+		text, err := numberText(obj[part])
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer part %q: %w", part, err)
+		}
+		word, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer part %q", part)
+		}
+		if idx > 0 {
+			value.Lsh(value, 64)
+		}
+		value.Add(value, word)
+	}
+	return value, nil
+}
+
+func parseHex(data []byte) ([]byte, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return hex.DecodeString(s)
+}
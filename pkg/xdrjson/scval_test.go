@@ -0,0 +1,100 @@
+package xdrjson
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tryoutbounder/soroban-client-golang/pkg/helpers"
+)
+
+func TestUnmarshalScVal(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+
+	for _, tc := range []struct {
+		json     string
+		expected xdr.ScVal
+	}{
+		{`"void"`, xdr.ScVal{Type: xdr.ScValTypeScvVoid}},
+		{`{"u32":7}`, mustScVal(t, xdr.ScValTypeScvU32, xdr.Uint32(7))},
+		{`{"i64":"-9000000000"}`, mustScVal(t, xdr.ScValTypeScvI64, xdr.Int64(-9000000000))},
+		{`{"i128":"-1"}`, mustScVal(t, xdr.ScValTypeScvI128, xdr.Int128Parts{Hi: -1, Lo: ^xdr.Uint64(0)})},
+		{`{"i128":{"hi":0,"lo":5}}`, mustScVal(t, xdr.ScValTypeScvI128, xdr.Int128Parts{Lo: 5})},
+		{`{"symbol":"balance"}`, mustScVal(t, xdr.ScValTypeScvSymbol, xdr.ScSymbol("balance"))},
+		{`{"bytes":"0a0b"}`, mustScVal(t, xdr.ScValTypeScvBytes, xdr.ScBytes{0x0a, 0x0b})},
+		{`{"error":{"contract":1205}}`, mustScVal(t, xdr.ScValTypeScvError, xdr.ScError{
+			Type:         xdr.ScErrorTypeSceContract,
+			ContractCode: func() *xdr.Uint32 { c := xdr.Uint32(1205); return &c }(),
+		})},
+	} {
+		t.Run(tc.json, func(t *testing.T) {
+			val, err := UnmarshalScVal([]byte(tc.json))
+			require.NoError(t, err)
+			assert.True(t, val.Equals(tc.expected), "got %v", val)
+		})
+	}
+
+	val, err := UnmarshalScVal([]byte(`{"map":[{"key":{"symbol":"to"},"val":{"address":"` + contract + `"}}]}`))
+	require.NoError(t, err)
+	scMap, ok := val.GetMap()
+	require.True(t, ok)
+	require.Len(t, *scMap, 1)
+	address, err := (*scMap)[0].Val.MustAddress().String()
+	require.NoError(t, err)
+	assert.Equal(t, contract, address)
+}
+
+func TestMarshalScValRoundTrip(t *testing.T) {
+	big128, ok := new(big.Int).SetString("-170141183460469231731687303715884105728", 10)
+	require.True(t, ok)
+	i128, err := helpers.BigIntToI128(big128)
+	require.NoError(t, err)
+
+	vec := xdr.ScVec{
+		mustScVal(t, xdr.ScValTypeScvI128, i128),
+		mustScVal(t, xdr.ScValTypeScvString, xdr.ScString("hi")),
+		xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+	}
+	original := mustScVal(t, xdr.ScValTypeScvVec, &vec)
+
+	data, err := MarshalScVal(original)
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"vec":[{"i128":"-170141183460469231731687303715884105728"},{"string":"hi"},"ledger_key_contract_instance"]}`,
+		string(data),
+	)
+
+	decoded, err := UnmarshalScVal(data)
+	require.NoError(t, err)
+	assert.True(t, decoded.Equals(original))
+}
+
+func TestUnmarshalDiagnosticEvent(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+	event, err := UnmarshalDiagnosticEvent([]byte(`{
+		"in_successful_contract_call": false,
+		"event": {
+			"ext": "v0",
+			"contract_id": "` + contract + `",
+			"type_": "diagnostic",
+			"body": {"v0": {"topics": [{"symbol": "error"}, {"error": {"contract": 3}}], "data": "void"}}
+		}
+	}`))
+	require.NoError(t, err)
+	assert.False(t, event.InSuccessfulContractCall)
+	assert.Equal(t, xdr.ContractEventTypeDiagnostic, event.Event.Type)
+	require.NotNil(t, event.Event.ContractId)
+	topics := event.Event.Body.MustV0().Topics
+	require.Len(t, topics, 2)
+	assert.Equal(t, xdr.Uint32(3), *topics[1].MustError().ContractCode)
+}
+
+func mustScVal(t *testing.T, valType xdr.ScValType, value any) xdr.ScVal {
+	val, err := xdr.NewScVal(valType, value)
+	require.NoError(t, err)
+	return val
+}
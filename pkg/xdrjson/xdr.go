@@ -0,0 +1,417 @@
+package xdrjson
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+// Decode decodes whichever form of an XDR value a response carries: the
+// base64 XDR if it is set, otherwise the JSON returned for xdrFormat=json.
+// It returns an error if neither is set.
+func Decode(xdrBase64 string, jsonData json.RawMessage, out any) error {
+	if xdrBase64 != "" {
+		return xdr.SafeUnmarshalBase64(xdrBase64, out)
+	}
+	if len(jsonData) == 0 {
+		return fmt.Errorf("missing %T", out)
+	}
+	return Unmarshal(jsonData, out)
+}
+
+// Unmarshal parses the JSON form of any XDR type into out, which must be a
+// pointer to a type from github.com/stellar/go/xdr.
+//
+// Struct fields are the snake_case XDR field names, unions are objects keyed
+// by their snake_case case name (or just the name for void arms), and enum
+// names drop the prefix their members share, as stellar-xdr does. Account,
+// contract and signer keys are strkeys.
+func Unmarshal(data []byte, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("xdrjson: Unmarshal needs a non-nil pointer, got %T", out)
+	}
+	return unmarshalValue(bytes.TrimSpace(data), v.Elem())
+}
+
+type xdrUnion interface {
+	SwitchFieldName() string
+	ArmForSwitch(sw int32) (string, bool)
+}
+
+type xdrEnum interface {
+	ValidEnum(v int32) bool
+	String() string
+}
+
+var (
+	scValType        = reflect.TypeFor[xdr.ScVal]()
+	scAddressType    = reflect.TypeFor[xdr.ScAddress]()
+	muxedAccountType = reflect.TypeFor[xdr.MuxedAccount]()
+	accountIdType    = reflect.TypeFor[xdr.AccountId]()
+	publicKeyType    = reflect.TypeFor[xdr.PublicKey]()
+	nodeIdType       = reflect.TypeFor[xdr.NodeId]()
+	signerKeyType    = reflect.TypeFor[xdr.SignerKey]()
+	contractIdType   = reflect.TypeFor[xdr.ContractId]()
+	poolIdType       = reflect.TypeFor[xdr.PoolId]()
+	assetCode4Type   = reflect.TypeFor[xdr.AssetCode4]()
+	assetCode12Type  = reflect.TypeFor[xdr.AssetCode12]()
+	unionType        = reflect.TypeFor[xdrUnion]()
+	enumType         = reflect.TypeFor[xdrEnum]()
+)
+
+func unmarshalValue(data []byte, v reflect.Value) error {
+	if string(data) == "null" {
+		if v.Kind() != reflect.Pointer {
+			return fmt.Errorf("unexpected null for %s", v.Type())
+		}
+		v.SetZero()
+		return nil
+	}
+
+	if handled, err := unmarshalSpecial(data, v); handled {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := unmarshalValue(data, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Struct:
+		if v.Type().Implements(unionType) {
+			return unmarshalUnion(data, v)
+		}
+		return unmarshalStruct(data, v)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			raw, err := parseHex(data)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", v.Type(), err)
+			}
+			v.SetBytes(raw)
+			return nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(items), len(items)))
+		for idx, item := range items {
+			if err := unmarshalValue(bytes.TrimSpace(item), v.Index(idx)); err != nil {
+				return fmt.Errorf("item %d: %w", idx, err)
+			}
+		}
+		return nil
+	case reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported array type %s", v.Type())
+		}
+		raw, err := parseHex(data)
+		if err != nil || len(raw) != v.Len() {
+			return fmt.Errorf("invalid %s %s", v.Type(), data)
+		}
+		reflect.Copy(v, reflect.ValueOf(raw))
+		return nil
+	case reflect.String:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int32:
+		if v.Type().Implements(enumType) {
+			var name string
+			if err := json.Unmarshal(data, &name); err != nil {
+				return fmt.Errorf("invalid %s: %w", v.Type(), err)
+			}
+			value, ok := enumNamesOf(v.Type()).values[name]
+			if !ok {
+				return fmt.Errorf("unknown %s %q", v.Type(), name)
+			}
+			v.SetInt(int64(value))
+			return nil
+		}
+		fallthrough
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		n, err := parseInt(data, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseUint(data, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+		v.SetUint(n)
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// unmarshalSpecial decodes the types whose JSON form is not derived from
+// their XDR definition, and reports whether v was one of them.
+func unmarshalSpecial(data []byte, v reflect.Value) (bool, error) {
+	var parsed any
+	var err error
+	switch v.Type() {
+	case scValType:
+		parsed, err = UnmarshalScVal(data)
+	case scAddressType:
+		parsed, err = unmarshalAddress(data)
+	case muxedAccountType:
+		parsed, err = unmarshalStrkey(data, xdr.AddressToMuxedAccount)
+	case accountIdType, publicKeyType, nodeIdType:
+		var accountId xdr.AccountId
+		accountId, err = unmarshalStrkey(data, xdr.AddressToAccountId)
+		parsed = reflect.ValueOf(accountId).Convert(v.Type()).Interface()
+	case signerKeyType:
+		parsed, err = unmarshalStrkey(data, func(address string) (xdr.SignerKey, error) {
+			var signerKey xdr.SignerKey
+			err := signerKey.SetAddress(address)
+			return signerKey, err
+		})
+	case contractIdType:
+		parsed, err = unmarshalStrkey(data, parseContractID)
+	case poolIdType:
+		parsed, err = unmarshalStrkey(data, parsePoolID)
+	case assetCode4Type, assetCode12Type:
+		var code string
+		if err := json.Unmarshal(data, &code); err != nil {
+			return true, fmt.Errorf("invalid asset code: %w", err)
+		}
+		if len(code) > v.Len() {
+			return true, fmt.Errorf("asset code %q is longer than %d characters", code, v.Len())
+		}
+		v.SetZero()
+		reflect.Copy(v, reflect.ValueOf([]byte(code)))
+		return true, nil
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	v.Set(reflect.ValueOf(parsed))
+	return true, nil
+}
+
+func unmarshalStrkey[T any](data []byte, parse func(string) (T, error)) (T, error) {
+	var address string
+	if err := json.Unmarshal(data, &address); err != nil {
+		var zero T
+		return zero, fmt.Errorf("invalid %T: %w", zero, err)
+	}
+	return parse(address)
+}
+
+// parsePoolID accepts a liquidity pool strkey or a hex hash.
+func parsePoolID(s string) (xdr.PoolId, error) {
+	var poolID xdr.PoolId
+
+	raw, err := strkey.Decode(strkey.VersionByteLiquidityPool, s)
+	if err != nil {
+		raw, err = hex.DecodeString(s)
+		if err != nil || len(raw) != len(poolID) {
+			return poolID, fmt.Errorf("invalid liquidity pool id %q", s)
+		}
+	}
+	copy(poolID[:], raw)
+	return poolID, nil
+}
+
+func unmarshalStruct(data []byte, v reflect.Value) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("invalid %s: %w", v.Type(), err)
+	}
+
+	fields := make(map[string]int, v.NumField())
+	for idx := range v.NumField() {
+		fields[fieldName(v.Type().Field(idx).Name)] = idx
+	}
+
+	v.SetZero()
+	for key, value := range obj {
+		idx, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("unknown %s field %q", v.Type(), key)
+		}
+		if err := unmarshalValue(bytes.TrimSpace(value), v.Field(idx)); err != nil {
+			return fmt.Errorf("%s.%s: %w", v.Type().Name(), key, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalUnion(data []byte, v reflect.Value) error {
+	var name string
+	var value json.RawMessage
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &name); err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+	} else {
+		var err error
+		name, value, err = singleKey(data)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", v.Type(), err)
+		}
+	}
+
+	v.SetZero()
+	union := v.Interface().(xdrUnion)
+	switchField := v.FieldByName(union.SwitchFieldName())
+
+	var sw int32
+	if switchField.Type().Implements(enumType) {
+		value, ok := enumNamesOf(switchField.Type()).values[name]
+		if !ok {
+			return fmt.Errorf("unknown %s case %q", v.Type(), name)
+		}
+		sw = value
+	} else if _, err := fmt.Sscanf(name, "v%d", &sw); err != nil {
+		return fmt.Errorf("unknown %s case %q", v.Type(), name)
+	}
+
+	armName, ok := union.ArmForSwitch(sw)
+	if !ok {
+		return fmt.Errorf("unknown %s case %q", v.Type(), name)
+	}
+	switchField.SetInt(int64(sw))
+
+	if armName == "" {
+		return nil
+	}
+	if value == nil {
+		return fmt.Errorf("%s case %q needs a value", v.Type(), name)
+	}
+	if err := unmarshalValue(bytes.TrimSpace(value), v.FieldByName(armName)); err != nil {
+		return fmt.Errorf("%s.%s: %w", v.Type().Name(), name, err)
+	}
+	return nil
+}
+
+// fieldName is the stellar-xdr name of a Go struct field.
+func fieldName(goName string) string {
+	name := snakeCase(goName)
+	if name == "type" {
+		// type is a Rust keyword.
+		return "type_"
+	}
+	return name
+}
+
+type enumNames struct {
+	values map[string]int32
+}
+
+var enumCache sync.Map // reflect.Type -> *enumNames
+
+// keepPrefixEnums are enums whose XDR member names share a prefix that is
+// not separated by an underscore (txSUCCESS, opINNER), which stellar-xdr
+// keeps.
+var keepPrefixEnums = map[reflect.Type]bool{
+	reflect.TypeFor[xdr.TransactionResultCode](): true,
+	reflect.TypeFor[xdr.OperationResultCode]():   true,
+}
+
+// enumNamesOf maps the stellar-xdr names of an enum's members to their
+// values. The Go member names are the type name followed by the camel-cased
+// XDR name, so the prefix the members share is found word by word.
+func enumNamesOf(t reflect.Type) *enumNames {
+	if cached, ok := enumCache.Load(t); ok {
+		return cached.(*enumNames)
+	}
+
+	var values []int32
+	var words [][]string
+	probe := reflect.New(t).Elem()
+	enum := probe.Interface().(xdrEnum)
+	for value := int32(-1024); value <= 4096; value++ {
+		if !enum.ValidEnum(value) {
+			continue
+		}
+		probe.SetInt(int64(value))
+		name := strings.TrimPrefix(probe.Interface().(xdrEnum).String(), t.Name())
+		values = append(values, value)
+		words = append(words, camelWords(name))
+	}
+
+	prefix := 0
+	if len(words) > 1 && !keepPrefixEnums[t] {
+		prefix = commonPrefix(words)
+	}
+
+	names := &enumNames{values: make(map[string]int32, len(values))}
+	for idx, value := range values {
+		names.values[snakeCase(strings.Join(words[idx][prefix:], ""))] = value
+	}
+	cached, _ := enumCache.LoadOrStore(t, names)
+	return cached.(*enumNames)
+}
+
+// commonPrefix counts the leading words all names share, leaving at least
+// one word of each.
+func commonPrefix(names [][]string) int {
+	prefix := len(names[0]) - 1
+	for _, name := range names[1:] {
+		prefix = min(prefix, len(name)-1)
+		for idx := range prefix {
+			if name[idx] != names[0][idx] {
+				prefix = idx
+				break
+			}
+		}
+	}
+	return max(prefix, 0)
+}
+
+// camelWords splits a camel-cased name before each upper case letter.
+func camelWords(name string) []string {
+	var words []string
+	start := 0
+	for idx, r := range name {
+		if idx > 0 && unicode.IsUpper(r) {
+			words = append(words, name[start:idx])
+			start = idx
+		}
+	}
+	return append(words, name[start:])
+}
+
+func snakeCase(name string) string {
+	var sb strings.Builder
+	for idx, r := range name {
+		if unicode.IsUpper(r) {
+			if idx > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package xdrjson
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumNames(t *testing.T) {
+	for _, tc := range []struct {
+		enum     any
+		name     string
+		expected int32
+	}{
+		{xdr.ScValTypeScvBool, "bool", 0},
+		{xdr.EnvelopeTypeEnvelopeTypeTx, "tx", 2},
+		{xdr.EnvelopeTypeEnvelopeTypeTxFeeBump, "tx_fee_bump", 5},
+		{xdr.LedgerEntryTypeContractData, "contract_data", 6},
+		{xdr.ContractIdPreimageTypeContractIdPreimageFromAsset, "asset", 1},
+		{xdr.TransactionResultCodeTxSuccess, "tx_success", 0},
+		{xdr.OperationResultCodeOpInner, "op_inner", 0},
+		{xdr.InvokeHostFunctionResultCodeInvokeHostFunctionTrapped, "trapped", -2},
+		{xdr.PublicKeyTypePublicKeyTypeEd25519, "public_key_type_ed25519", 0},
+		{xdr.AssetTypeAssetTypeCreditAlphanum4, "credit_alphanum4", 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values := enumNamesOf(reflect.TypeOf(tc.enum)).values
+			value, ok := values[tc.name]
+			require.True(t, ok, "names: %v", values)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestUnmarshalTransactionResult(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	data := `{"fee_charged":"100","result":{"tx_success":[{"op_inner":{"invoke_host_function":{"success":"` + hash + `"}}}]},"ext":"v0"}`
+
+	var result xdr.TransactionResult
+	require.NoError(t, Unmarshal([]byte(data), &result))
+	assert.Equal(t, xdr.Int64(100), result.FeeCharged)
+	assert.True(t, result.Successful())
+
+	opResults, ok := result.OperationResults()
+	require.True(t, ok)
+	require.Len(t, opResults, 1)
+	invokeResult := opResults[0].MustTr().MustInvokeHostFunctionResult()
+	assert.Equal(t, xdr.InvokeHostFunctionResultCodeInvokeHostFunctionSuccess, invokeResult.Code)
+	assert.Equal(t, hash, fmt.Sprintf("%x", *invokeResult.Success))
+
+	data = `{"fee_charged":"100","result":{"tx_failed":[{"op_inner":{"invoke_host_function":"trapped"}}]},"ext":"v0"}`
+	require.NoError(t, Unmarshal([]byte(data), &result))
+	assert.Equal(t, xdr.TransactionResultCodeTxFailed, result.Result.Code)
+	opResults, _ = result.OperationResults()
+	assert.Equal(t, xdr.InvokeHostFunctionResultCodeInvokeHostFunctionTrapped, opResults[0].MustTr().MustInvokeHostFunctionResult().Code)
+}
+
+func TestUnmarshalTransactionEnvelope(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+	data := `{"tx":{"tx":{
+		"source_account":"` + source + `","fee":250,"seq_num":"12","cond":"none","memo":{"text":"hi"},
+		"operations":[{"source_account":null,"body":{"invoke_host_function":{
+			"host_function":{"invoke_contract":{"contract_address":"` + contract + `","function_name":"balance","args":[{"u32":1}]}},
+			"auth":[]}}}],
+		"ext":{"v1":{"ext":"v0","resources":{"footprint":{"read_only":[{"contract_data":{"contract":"` + contract + `","key":"ledger_key_contract_instance","durability":"persistent"}}],"read_write":[]},"instructions":100,"disk_read_bytes":0,"write_bytes":0},"resource_fee":"150"}}},
+		"signatures":[{"hint":"01020304","signature":"aabb"}]}}`
+
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, Unmarshal([]byte(data), &envelope))
+	require.Equal(t, xdr.EnvelopeTypeEnvelopeTypeTx, envelope.Type)
+	tx := envelope.V1.Tx
+	assert.Equal(t, source, tx.SourceAccount.Address())
+	assert.Equal(t, xdr.Uint32(250), tx.Fee)
+	assert.Equal(t, xdr.SequenceNumber(12), tx.SeqNum)
+	assert.Equal(t, "hi", tx.Memo.MustText())
+	assert.Equal(t, xdr.ScSymbol("balance"), tx.Operations[0].Body.MustInvokeHostFunctionOp().HostFunction.MustInvokeContract().FunctionName)
+	assert.Equal(t, xdr.Int64(150), tx.Ext.SorobanData.ResourceFee)
+	require.Len(t, tx.Ext.SorobanData.Resources.Footprint.ReadOnly, 1)
+	assert.Equal(t, xdr.ScValTypeScvLedgerKeyContractInstance, tx.Ext.SorobanData.Resources.Footprint.ReadOnly[0].ContractData.Key.Type)
+	assert.Equal(t, xdr.SignatureHint{1, 2, 3, 4}, envelope.V1.Signatures[0].Hint)
+
+	// The decoded envelope encodes to valid XDR.
+	_, err := xdr.MarshalBase64(envelope)
+	assert.NoError(t, err)
+}
+
+func TestUnmarshalTransactionMeta(t *testing.T) {
+	contract := strkey.MustEncode(strkey.VersionByteContract, make([]byte, 32))
+	data := `{"v4":{"ext":"v0","tx_changes_before":[],"operations":[{"ext":"v0","changes":[],"events":[
+		{"ext":"v0","contract_id":"` + contract + `","type_":"contract","body":{"v0":{"topics":[{"symbol":"mint"}],"data":{"i128":"5"}}}}]}],
+		"tx_changes_after":[],"soroban_meta":{"ext":"v0","return_value":{"bool":true}},"events":[],"diagnostic_events":[]}}`
+
+	var meta xdr.TransactionMeta
+	require.NoError(t, Unmarshal([]byte(data), &meta))
+	require.Equal(t, int32(4), meta.V)
+	returnValue := meta.V4.SorobanMeta.ReturnValue
+	require.NotNil(t, returnValue)
+	assert.True(t, returnValue.MustB())
+
+	events, err := meta.GetContractEventsForOperation(0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, xdr.ContractEventTypeContract, events[0].Type)
+	assert.Equal(t, xdr.ScSymbol("mint"), events[0].Body.V0.Topics[0].MustSym())
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var result xdr.TransactionResult
+	assert.Error(t, Unmarshal([]byte(`{"fee_charged":"100","result":"no_such_code","ext":"v0"}`), &result))
+	assert.Error(t, Unmarshal([]byte(`{"fee":"100"}`), &result))
+
+	var data xdr.SorobanTransactionData
+	assert.Error(t, Unmarshal([]byte(`{}`), data))
+
+	assert.Error(t, Decode("", nil, &data))
+}